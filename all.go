@@ -0,0 +1,121 @@
+package nested
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Match is a value found by All with the position where it was found,
+// the position has the keys and the indexes of slices separately by . (dot).
+type Match struct {
+	Path  string
+	Value interface{}
+}
+
+// All returns all values from position that you pass separately by . (dot),
+// the segment * matches all keys of a map or all elements of a slice and
+// the segment ** matches any number of levels, including none.
+// The matches are sorted by the keys of maps and the indexes of slices.
+func (m Map) All(position string) []Match {
+	pos := splitPosition(position)
+	if pos == nil {
+		return nil
+	}
+
+	c := collector{}
+	for _, seg := range pos {
+		if seg.recursive {
+			c.seen = make(map[string]bool)
+			break
+		}
+	}
+
+	c.collect(map[string]interface{}(m), "", pos)
+	return c.matches
+}
+
+// collector keeps the matches found by All.
+type collector struct {
+	matches []Match
+	seen    map[string]bool
+}
+
+// collect appends the values in pos starting from node, path is the position of node.
+func (c *collector) collect(node interface{}, path string, pos []segment) {
+	if len(pos) == 0 {
+		if c.seen != nil {
+			if c.seen[path] {
+				return
+			}
+			c.seen[path] = true
+		}
+
+		c.matches = append(c.matches, Match{Path: path, Value: node})
+		return
+	}
+
+	seg := pos[0]
+	switch {
+	case seg.recursive:
+		c.collect(node, path, pos[1:])
+		for _, item := range children(node) {
+			c.collect(item.Value, keyPosition(path, item.Path), pos)
+		}
+	case seg.wildcard:
+		for _, item := range children(node) {
+			c.collect(item.Value, keyPosition(path, item.Path), pos[1:])
+		}
+	default:
+		v, ok := child(node, seg)
+		if !ok {
+			return
+		}
+
+		key := seg.key
+		if index, ok := sliceIndex(seg, sliceLen(node)); ok {
+			key = strconv.Itoa(index)
+		}
+		c.collect(v, keyPosition(path, key), pos[1:])
+	}
+}
+
+// children returns the values inside of node with their keys or indexes,
+// the keys of maps are sorted.
+func children(node interface{}) []Match {
+	rv := reflect.ValueOf(node)
+	switch rv.Kind() {
+	case reflect.Map:
+		items := make([]Match, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			items = append(items, Match{Path: fmt.Sprint(iter.Key().Interface()), Value: iter.Value().Interface()})
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].Path < items[j].Path })
+		return items
+	case reflect.Slice, reflect.Array:
+		items := make([]Match, rv.Len())
+		for i := range items {
+			items[i] = Match{Path: strconv.Itoa(i), Value: rv.Index(i).Interface()}
+		}
+		return items
+	}
+
+	return nil
+}
+
+// sliceLen returns the length of node if it is a slice or an array, otherwise -1.
+func sliceLen(node interface{}) int {
+	rv := reflect.ValueOf(node)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		return rv.Len()
+	}
+	return -1
+}
+
+// All is helper for function All from Map.
+func All(position string, mapper map[string]interface{}) []Match {
+	return New(mapper).All(position)
+}
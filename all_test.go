@@ -0,0 +1,115 @@
+package nested
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	order := map[string]interface{}{
+		"id": "12",
+		"items": []interface{}{
+			map[string]interface{}{"name": "book", "price": 10.5},
+			map[string]interface{}{"name": "pen", "price": 2},
+			map[string]interface{}{"name": "bag"},
+		},
+		"labels": map[string]interface{}{"k8s.io/name": "api"},
+		"shipping": map[string]interface{}{
+			"price": 5,
+			"address": map[string]interface{}{
+				"city": "Lisbon",
+			},
+		},
+	}
+
+	tests := []struct {
+		Parameter string
+		Expected  []Match
+	}{
+		{
+			Parameter: "items.*.price",
+			Expected: []Match{
+				{Path: "items.0.price", Value: 10.5},
+				{Path: "items.1.price", Value: 2},
+			},
+		},
+		{
+			Parameter: "items[*].name",
+			Expected: []Match{
+				{Path: "items.0.name", Value: "book"},
+				{Path: "items.1.name", Value: "pen"},
+				{Path: "items.2.name", Value: "bag"},
+			},
+		},
+		{
+			Parameter: "**.price",
+			Expected: []Match{
+				{Path: "items.0.price", Value: 10.5},
+				{Path: "items.1.price", Value: 2},
+				{Path: "shipping.price", Value: 5},
+			},
+		},
+		{
+			Parameter: "shipping.**",
+			Expected: []Match{
+				{Path: "shipping", Value: order["shipping"]},
+				{Path: "shipping.address", Value: map[string]interface{}{"city": "Lisbon"}},
+				{Path: "shipping.address.city", Value: "Lisbon"},
+				{Path: "shipping.price", Value: 5},
+			},
+		},
+		{
+			Parameter: "**.**.city",
+			Expected: []Match{
+				{Path: "shipping.address.city", Value: "Lisbon"},
+			},
+		},
+		{
+			Parameter: "items[-1].name",
+			Expected: []Match{
+				{Path: "items.2.name", Value: "bag"},
+			},
+		},
+		{
+			Parameter: "shipping.*",
+			Expected: []Match{
+				{Path: "shipping.address", Value: map[string]interface{}{"city": "Lisbon"}},
+				{Path: "shipping.price", Value: 5},
+			},
+		},
+		{
+			Parameter: "labels.*",
+			Expected: []Match{
+				{Path: `labels.k8s\.io/name`, Value: "api"},
+			},
+		},
+		{
+			Parameter: "id.*",
+			Expected:  nil,
+		},
+		{
+			Parameter: "bananas.*",
+			Expected:  nil,
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual := All(test.Parameter, order)
+			if !reflect.DeepEqual(test.Expected, actual) {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.Expected, actual)
+			}
+		})
+	}
+}
+func ExampleMap_All() {
+	data, _ := NewFromJSON(`{"items": [{"price": 10.5}, {"price": 2}]}`)
+
+	for _, match := range data.All("items.*.price") {
+		fmt.Println(match.Path, match.Value)
+	}
+	// output:
+	// items.0.price 10.5
+	// items.1.price 2
+}
@@ -0,0 +1,85 @@
+package nested
+
+import (
+	"time"
+)
+
+// MustCompile parses the position like ParsePath and panics if the position is not valid,
+// it is useful to keep the paths that are used many times in global variables.
+func MustCompile(position string) Path {
+	p, err := ParsePath(position)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// LookupE returns the value of path in m like InterfaceE without parsing the position again.
+func (p Path) LookupE(m Map, opts ...Option) (interface{}, error) {
+	return find(m, p.position, p.segments, opts)
+}
+
+// Lookup returns the value of path in m like Interface without parsing the position again,
+// it doesn't allocate memory.
+func (p Path) Lookup(m Map, opts ...Option) (interface{}, bool) {
+	value, missing := walk(map[string]interface{}(m), p.segments, newOptions(opts).loose)
+	return value, missing < 0
+}
+
+// String returns the string value of path in m like Map.String.
+func (p Path) String(m Map) (string, bool) {
+	return GetPath[string](m, p)
+}
+
+// Int returns the int value of path in m like Map.Int.
+func (p Path) Int(m Map, opts ...Option) (int, bool) {
+	return GetPath[int](m, p, opts...)
+}
+
+// Int64 returns the int64 value of path in m like Map.Int64.
+func (p Path) Int64(m Map, opts ...Option) (int64, bool) {
+	return GetPath[int64](m, p, opts...)
+}
+
+// Uint returns the uint value of path in m like Map.Uint.
+func (p Path) Uint(m Map, opts ...Option) (uint, bool) {
+	return GetPath[uint](m, p, opts...)
+}
+
+// Uint64 returns the uint64 value of path in m like Map.Uint64.
+func (p Path) Uint64(m Map, opts ...Option) (uint64, bool) {
+	return GetPath[uint64](m, p, opts...)
+}
+
+// Float64 returns the float64 value of path in m like Map.Float64.
+func (p Path) Float64(m Map, opts ...Option) (float64, bool) {
+	return GetPath[float64](m, p, opts...)
+}
+
+// Bool returns the bool value of path in m like Map.Bool.
+func (p Path) Bool(m Map) (bool, bool) {
+	return GetPath[bool](m, p)
+}
+
+// Time returns the time.Time value of path in m parsed with layout like Map.Time.
+func (p Path) Time(m Map, layout string) (time.Time, bool) {
+	return GetPath[time.Time](m, p, Layout(layout))
+}
+
+// GetPath returns the value of path in m converted to the type T like Get without parsing the position again,
+// values that are already T are returned without allocating memory.
+func GetPath[T any](m Map, p Path, opts ...Option) (T, bool) {
+	value, err := GetPathE[T](m, p, opts...)
+	return value, err == nil
+}
+
+// GetPathE returns the value of path in m converted to the type T like GetE without parsing the position again.
+func GetPathE[T any](m Map, p Path, opts ...Option) (T, error) {
+	valueTmp, err := p.LookupE(m, opts...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return convertTo[T](p.position, valueTmp, opts)
+}
@@ -0,0 +1,115 @@
+package nested
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestPath(t *testing.T) {
+	m := New(data)
+
+	tests := []struct {
+		Path          Path
+		ExpectedFirst interface{}
+		Found         bool
+	}{
+		{Path: MustCompile("advert.id"), ExpectedFirst: "12", Found: true},
+		{Path: MustCompile("advert.contact.phones[1]"), ExpectedFirst: "790123546", Found: true},
+		{Path: MustCompile("/advert/status/code"), ExpectedFirst: "active", Found: true},
+		{Path: MustCompile("advert.missing"), ExpectedFirst: nil, Found: false},
+		{Path: Path{}, ExpectedFirst: nil, Found: false},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, found := test.Path.Lookup(m)
+			if found != test.Found {
+				t.Errorf("[%s] expected found %v, but got %v", test.Path.Position(), test.Found, found)
+			}
+			if actual != test.ExpectedFirst {
+				t.Errorf("[%s] expected %v, but got %v", test.Path.Position(), test.ExpectedFirst, actual)
+			}
+		})
+	}
+}
+
+func TestPathGetters(t *testing.T) {
+	m := New(map[string]interface{}{
+		"name":    "daniel",
+		"level":   3,
+		"price":   19.9,
+		"active":  true,
+		"created": "2020-02-13",
+	})
+
+	if value, ok := MustCompile("name").String(m); !ok || value != "daniel" {
+		t.Errorf("expected daniel, but got %v", value)
+	}
+	if value, ok := MustCompile("level").Int(m); !ok || value != 3 {
+		t.Errorf("expected 3, but got %v", value)
+	}
+	if value, ok := MustCompile("level").Uint64(m); !ok || value != 3 {
+		t.Errorf("expected 3, but got %v", value)
+	}
+	if value, ok := MustCompile("price").Float64(m); !ok || value != 19.9 {
+		t.Errorf("expected 19.9, but got %v", value)
+	}
+	if value, ok := MustCompile("active").Bool(m); !ok || !value {
+		t.Errorf("expected true, but got %v", value)
+	}
+	if value, ok := MustCompile("created").Time(m, "2006-01-02"); !ok || !value.Equal(time.Date(2020, 2, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 2020-02-13, but got %v", value)
+	}
+	if _, ok := MustCompile("name").Int(m); ok {
+		t.Error("expected false for name as int")
+	}
+
+	var mismatch *TypeMismatchError
+	if _, err := GetPathE[int](m, MustCompile("name")); !errors.As(err, &mismatch) || mismatch.Path != "name" {
+		t.Errorf("expected *TypeMismatchError for name, but got %v", err)
+	}
+
+	var notFound *PathNotFoundError
+	if _, err := MustCompile("person.name").LookupE(m); !errors.As(err, &notFound) || notFound.MissingSegment != "person" {
+		t.Errorf("expected *PathNotFoundError for person, but got %v", err)
+	}
+}
+
+func TestPathAllocs(t *testing.T) {
+	m := New(data)
+	lookup := MustCompile("advert.status.code")
+	name := MustCompile("advert.contact.name")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		lookup.Lookup(m)
+		name.String(m)
+	})
+	if allocs != 0 {
+		t.Errorf("expected 0 allocations, but got %v", allocs)
+	}
+}
+
+func TestMustCompile(t *testing.T) {
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrInvalidPosition) {
+			t.Errorf("expected panic with %v, but got %v", ErrInvalidPosition, err)
+		}
+	}()
+
+	MustCompile("phones[0")
+}
+
+func ExampleMustCompile() {
+	name := MustCompile("person.name")
+
+	for _, doc := range []string{`{"person": {"name": "daniel"}}`, `{"person": {"name": "rod"}}`} {
+		m, _ := NewFromJSON(doc)
+		value, _ := name.String(m)
+		fmt.Println(value)
+	}
+	// output:
+	// daniel
+	// rod
+}
@@ -0,0 +1,369 @@
+package nested
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrOverflow when a number cannot be represented by the type requested.
+	ErrOverflow = errors.New("the number overflows the type")
+
+	// ErrFractional when a number with fractional part is converted to an integer type.
+	ErrFractional = errors.New("the number has a fractional part")
+)
+
+// ToInt converts value of any integer or float type, json.Number and
+// numeric strings (with Lenient option) to int.
+// It returns ErrOverflow or ErrFractional when the number doesn't fit in an int.
+func ToInt(value interface{}, opts ...Option) (int, error) {
+	if v, ok := value.(int); ok {
+		return v, nil
+	}
+
+	v, err := ToInt64(value, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if v < math.MinInt64>>(64-strconv.IntSize) || v > math.MaxInt64>>(64-strconv.IntSize) {
+		return 0, fmt.Errorf("%w: %d does not fit in int", ErrOverflow, v)
+	}
+	return int(v), nil
+}
+
+// ToInt64 converts value of any integer or float type, json.Number and
+// numeric strings (with Lenient option) to int64.
+// It returns ErrOverflow or ErrFractional when the number doesn't fit in an int64.
+func ToInt64(value interface{}, opts ...Option) (int64, error) {
+	return toInt64(value, newOptions(opts))
+}
+
+// toInt64 converts value to int64 like ToInt64 does using the options o.
+func toInt64(value interface{}, o options) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		u, _ := toUint64(v, o)
+		if u > math.MaxInt64 {
+			return 0, fmt.Errorf("%w: %d does not fit in int64", ErrOverflow, u)
+		}
+		return int64(u), nil
+	case float32:
+		return floatToInt64(float64(v))
+	case float64:
+		return floatToInt64(v)
+	case json.Number:
+		return parseInt64(string(v))
+	case string:
+		if o.lenient {
+			return parseInt64(v)
+		}
+	}
+
+	return 0, ErrInvalidInputType
+}
+
+// ToUint converts value of any integer or float type, json.Number and
+// numeric strings (with Lenient option) to uint.
+// It returns ErrOverflow for negative numbers or numbers that don't fit in an uint.
+func ToUint(value interface{}, opts ...Option) (uint, error) {
+	v, err := ToUint64(value, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if v > math.MaxUint64>>(64-strconv.IntSize) {
+		return 0, fmt.Errorf("%w: %d does not fit in uint", ErrOverflow, v)
+	}
+	return uint(v), nil
+}
+
+// ToUint64 converts value of any integer or float type, json.Number and
+// numeric strings (with Lenient option) to uint64.
+// It returns ErrOverflow for negative numbers or numbers that don't fit in an uint64.
+func ToUint64(value interface{}, opts ...Option) (uint64, error) {
+	return toUint64(value, newOptions(opts))
+}
+
+// toUint64 converts value to uint64 like ToUint64 does using the options o.
+func toUint64(value interface{}, o options) (uint64, error) {
+	switch v := value.(type) {
+	case uint:
+		return uint64(v), nil
+	case uint8:
+		return uint64(v), nil
+	case uint16:
+		return uint64(v), nil
+	case uint32:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	case uintptr:
+		return uint64(v), nil
+	case int, int8, int16, int32, int64:
+		i, _ := toInt64(v, o)
+		if i < 0 {
+			return 0, fmt.Errorf("%w: %d does not fit in uint64", ErrOverflow, i)
+		}
+		return uint64(i), nil
+	case float32:
+		return floatToUint64(float64(v))
+	case float64:
+		return floatToUint64(v)
+	case json.Number:
+		return parseUint64(string(v))
+	case string:
+		if o.lenient {
+			return parseUint64(v)
+		}
+	}
+
+	return 0, ErrInvalidInputType
+}
+
+// ToFloat64 converts value of any integer or float type, json.Number and
+// numeric strings (with Lenient option) to float64.
+// It returns ErrOverflow when the number is bigger than the max float64.
+func ToFloat64(value interface{}, opts ...Option) (float64, error) {
+	return toFloat64(value, newOptions(opts))
+}
+
+// toFloat64 converts value to float64 like ToFloat64 does using the options o.
+func toFloat64(value interface{}, o options) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int, int8, int16, int32, int64:
+		i, _ := toInt64(v, o)
+		return float64(i), nil
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		u, _ := toUint64(v, o)
+		return float64(u), nil
+	case json.Number:
+		return parseFloat64(string(v))
+	case string:
+		if o.lenient {
+			return parseFloat64(v)
+		}
+	}
+
+	return 0, ErrInvalidInputType
+}
+
+// floatToInt64 converts f to int64 if f is an integer inside of int64 range.
+func floatToInt64(f float64) (int64, error) {
+	if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("%w: %v does not fit in int64", ErrOverflow, f)
+	}
+	if math.Trunc(f) != f {
+		return 0, fmt.Errorf("%w: %v", ErrFractional, f)
+	}
+	return int64(f), nil
+}
+
+// floatToUint64 converts f to uint64 if f is an integer inside of uint64 range.
+func floatToUint64(f float64) (uint64, error) {
+	if math.IsNaN(f) || f < 0 || f >= math.MaxUint64 {
+		return 0, fmt.Errorf("%w: %v does not fit in uint64", ErrOverflow, f)
+	}
+	if math.Trunc(f) != f {
+		return 0, fmt.Errorf("%w: %v", ErrFractional, f)
+	}
+	return uint64(f), nil
+}
+
+// parseInt64 parses s as an integer, numbers like "12.0" or "1e3" are accepted as well.
+func parseInt64(s string) (int64, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		return v, nil
+	}
+	if isRangeError(err) {
+		return 0, fmt.Errorf("%w: %s does not fit in int64", ErrOverflow, s)
+	}
+
+	f, err := parseFloat64(s)
+	if err != nil {
+		return 0, err
+	}
+	return floatToInt64(f)
+}
+
+// parseUint64 parses s as an unsigned integer, numbers like "12.0" or "1e3" are accepted as well.
+func parseUint64(s string) (uint64, error) {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err == nil {
+		return v, nil
+	}
+	if isRangeError(err) {
+		return 0, fmt.Errorf("%w: %s does not fit in uint64", ErrOverflow, s)
+	}
+
+	f, err := parseFloat64(s)
+	if err != nil {
+		return 0, err
+	}
+	return floatToUint64(f)
+}
+
+// parseFloat64 parses s as a float64.
+func parseFloat64(s string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err == nil {
+		return v, nil
+	}
+	if isRangeError(err) {
+		return 0, fmt.Errorf("%w: %s does not fit in float64", ErrOverflow, s)
+	}
+	return 0, ErrInvalidInputType
+}
+
+// isRangeError returns true if err is a strconv.ErrRange.
+func isRangeError(err error) bool {
+	return errors.Is(err, strconv.ErrRange)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// convertValue converts value found in position to the type typ, it converts the numbers like ToInt64,
+// strings and epochs to time.Time using the layouts of options, and the elements of slices and maps one by one.
+// It returns *TypeMismatchError or *ParseError with the position of the value that cannot be converted.
+func convertValue(position string, value interface{}, typ reflect.Type, o options) (reflect.Value, error) {
+	if value == nil {
+		switch typ.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+			return reflect.Zero(typ), nil
+		}
+		return reflect.Value{}, mismatch(position, typ.String(), value, nil)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(typ) {
+		if typ.Kind() == reflect.Interface {
+			return rv, nil
+		}
+		return rv.Convert(typ), nil
+	}
+
+	if typ == timeType {
+		t, err := toTime(position, value, o)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(t), nil
+	}
+
+	out := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.String:
+		if rv.Kind() != reflect.String {
+			return reflect.Value{}, mismatch(position, typ.String(), value, nil)
+		}
+		out.SetString(rv.String())
+	case reflect.Bool:
+		if rv.Kind() != reflect.Bool {
+			return reflect.Value{}, mismatch(position, typ.String(), value, nil)
+		}
+		out.SetBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := toInt64(value, o)
+		if err == nil && out.OverflowInt(i) {
+			err = fmt.Errorf("%w: %d does not fit in %s", ErrOverflow, i, typ)
+		}
+		if err != nil {
+			return reflect.Value{}, mismatch(position, typ.String(), value, err)
+		}
+		out.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := toUint64(value, o)
+		if err == nil && out.OverflowUint(u) {
+			err = fmt.Errorf("%w: %d does not fit in %s", ErrOverflow, u, typ)
+		}
+		if err != nil {
+			return reflect.Value{}, mismatch(position, typ.String(), value, err)
+		}
+		out.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat64(value, o)
+		if err == nil && out.OverflowFloat(f) {
+			err = fmt.Errorf("%w: %v does not fit in %s", ErrOverflow, f, typ)
+		}
+		if err != nil {
+			return reflect.Value{}, mismatch(position, typ.String(), value, err)
+		}
+		out.SetFloat(f)
+	case reflect.Slice:
+		items, ok := toSlice(value)
+		if !ok {
+			return reflect.Value{}, mismatch(position, typ.String(), value, nil)
+		}
+
+		out.Set(reflect.MakeSlice(typ, len(items), len(items)))
+		for i, item := range items {
+			elem, err := convertValue(elementPosition(position, i), item, typ.Elem(), o)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Index(i).Set(elem)
+		}
+	case reflect.Map:
+		if rv.Kind() != reflect.Map {
+			return reflect.Value{}, mismatch(position, typ.String(), value, nil)
+		}
+
+		out.Set(reflect.MakeMapWithSize(typ, rv.Len()))
+		iter := rv.MapRange()
+		for iter.Next() {
+			name := fmt.Sprint(iter.Key().Interface())
+			key, ok := mapKey(out, name)
+			if !ok {
+				return reflect.Value{}, mismatch(keyPosition(position, name), typ.Key().String(), iter.Key().Interface(), nil)
+			}
+
+			elem, err := convertValue(keyPosition(position, name), iter.Value().Interface(), typ.Elem(), o)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.SetMapIndex(key, elem)
+		}
+	case reflect.Struct:
+		return convertStruct(position, value, typ, o)
+	case reflect.Ptr:
+		elem, err := convertValue(position, value, typ.Elem(), o)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.Set(reflect.New(typ.Elem()))
+		out.Elem().Set(elem)
+	default:
+		return reflect.Value{}, mismatch(position, typ.String(), value, nil)
+	}
+
+	return out, nil
+}
+
+// keyPosition returns the position of key inside of the map in position.
+func keyPosition(position, key string) string {
+	if position == "" {
+		return escapeKey(key)
+	}
+	if isPointer(position) {
+		return position + "/" + pointerEscaper.Replace(key)
+	}
+	return position + "." + escapeKey(key)
+}
@@ -0,0 +1,131 @@
+package nested
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestToInt64(t *testing.T) {
+	tests := []struct {
+		Value    interface{}
+		Options  []Option
+		Expected int64
+		Error    error
+	}{
+		{Value: 12, Expected: 12},
+		{Value: int8(-12), Expected: -12},
+		{Value: uint32(12), Expected: 12},
+		{Value: uint64(math.MaxUint64), Error: ErrOverflow},
+		{Value: float32(12), Expected: 12},
+		{Value: 12.0, Expected: 12},
+		{Value: 12.5, Error: ErrFractional},
+		{Value: 1e19, Error: ErrOverflow},
+		{Value: math.NaN(), Error: ErrOverflow},
+		{Value: json.Number("12"), Expected: 12},
+		{Value: json.Number("1e3"), Expected: 1000},
+		{Value: json.Number("9223372036854775808"), Error: ErrOverflow},
+		{Value: "12", Error: ErrInvalidInputType},
+		{Value: "12", Options: []Option{Lenient()}, Expected: 12},
+		{Value: "12.5", Options: []Option{Lenient()}, Error: ErrFractional},
+		{Value: "twelve", Options: []Option{Lenient()}, Error: ErrInvalidInputType},
+		{Value: true, Error: ErrInvalidInputType},
+		{Value: nil, Error: ErrInvalidInputType},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, err := ToInt64(test.Value, test.Options...)
+			if actual != test.Expected || !errors.Is(err, test.Error) {
+				t.Errorf("[%T(%v)] expected %v and error %v, but got %v and error %v",
+					test.Value, test.Value, test.Expected, test.Error, actual, err)
+			}
+		})
+	}
+}
+
+func TestToUint64(t *testing.T) {
+	tests := []struct {
+		Value    interface{}
+		Options  []Option
+		Expected uint64
+		Error    error
+	}{
+		{Value: uint64(math.MaxUint64), Expected: math.MaxUint64},
+		{Value: 12, Expected: 12},
+		{Value: -1, Error: ErrOverflow},
+		{Value: -1.0, Error: ErrOverflow},
+		{Value: 0.5, Error: ErrFractional},
+		{Value: json.Number("-1"), Error: ErrOverflow},
+		{Value: "12", Options: []Option{Lenient()}, Expected: 12},
+		{Value: "12", Error: ErrInvalidInputType},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, err := ToUint64(test.Value, test.Options...)
+			if actual != test.Expected || !errors.Is(err, test.Error) {
+				t.Errorf("[%T(%v)] expected %v and error %v, but got %v and error %v",
+					test.Value, test.Value, test.Expected, test.Error, actual, err)
+			}
+		})
+	}
+}
+
+func TestToInt(t *testing.T) {
+	tests := []struct {
+		Value    interface{}
+		Expected int
+		Error    error
+	}{
+		{Value: 12, Expected: 12},
+		{Value: int64(12), Expected: 12},
+		{Value: 12.0, Expected: 12},
+		{Value: json.Number("12"), Expected: 12},
+		{Value: "12", Error: ErrInvalidInputType},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, err := ToInt(test.Value)
+			if actual != test.Expected || !errors.Is(err, test.Error) {
+				t.Errorf("[%T(%v)] expected %v and error %v, but got %v and error %v",
+					test.Value, test.Value, test.Expected, test.Error, actual, err)
+			}
+		})
+	}
+}
+
+func TestToFloat64(t *testing.T) {
+	tests := []struct {
+		Value    interface{}
+		Options  []Option
+		Expected float64
+		Error    error
+	}{
+		{Value: 19.9, Expected: 19.9},
+		{Value: 12, Expected: 12},
+		{Value: uint8(12), Expected: 12},
+		{Value: json.Number("19.9"), Expected: 19.9},
+		{Value: json.Number("1e400"), Error: ErrOverflow},
+		{Value: "19.9", Options: []Option{Lenient()}, Expected: 19.9},
+		{Value: "19.9", Error: ErrInvalidInputType},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, err := ToFloat64(test.Value, test.Options...)
+			if actual != test.Expected || !errors.Is(err, test.Error) {
+				t.Errorf("[%T(%v)] expected %v and error %v, but got %v and error %v",
+					test.Value, test.Value, test.Expected, test.Error, actual, err)
+			}
+		})
+	}
+}
+func ExampleToInt() {
+	_, err := ToInt(12.5)
+	fmt.Println(err)
+	// output: the number has a fractional part: 12.5
+}
@@ -0,0 +1,219 @@
+package nested
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DecodeError has all errors found decoding the fields of a struct,
+// each error is a *TypeMismatchError or *ParseError with the position of the field.
+type DecodeError struct {
+	Errors []error
+}
+
+func (e *DecodeError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return "cannot decode: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors of fields.
+func (e *DecodeError) Unwrap() []error {
+	return e.Errors
+}
+
+// Is returns true if some error of fields is target, errors.Is follows Unwrap() []error only since Go 1.20.
+func (e *DecodeError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error of fields that matches target like errors.As.
+func (e *DecodeError) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Decode fills out with the value from position that you passed by argument, out must be a pointer.
+// The fields of structs are read from the keys named by the tag json, or the tag defined by the option TagName,
+// the fields without tag use the field name and the keys are compared case-insensitively if there is no exact match.
+// The values are converted like Get does, the tag layout changes the time layout of a field
+// and the tag option string (json:",string") converts numeric strings like the option Lenient.
+// The keys that are missing or null keep the field unchanged, the structs and the non-nil pointers to struct
+// of out are filled in place, an empty position decodes the whole Map.
+// It returns *DecodeError with all fields that cannot be decoded, the other fields are decoded anyway.
+func (m Map) Decode(position string, out interface{}, opts ...Option) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("%w: Decode needs a non-nil pointer, got %T", ErrInvalidInputType, out)
+	}
+
+	var valueTmp interface{} = m
+	if position != "" {
+		var err error
+		if valueTmp, err = m.InterfaceE(position, opts...); err != nil {
+			return err
+		}
+	}
+
+	return decodeInto(position, valueTmp, rv.Elem(), newOptions(opts))
+}
+
+// decodeInto sets out with the value found in position, the maps are decoded in the structs and
+// the pointers to struct of out, so the fields without key keep their values.
+func decodeInto(position string, value interface{}, out reflect.Value, o options) error {
+	typ := out.Type()
+	if value != nil && reflect.ValueOf(value).Kind() == reflect.Map {
+		switch {
+		case typ.Kind() == reflect.Struct && typ != timeType:
+			if errs := fillStruct(position, value, out, o); len(errs) > 0 {
+				return &DecodeError{Errors: errs}
+			}
+			return nil
+		case typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Struct && typ.Elem() != timeType:
+			if out.IsNil() {
+				out.Set(reflect.New(typ.Elem()))
+			}
+			return decodeInto(position, value, out.Elem(), o)
+		}
+	}
+
+	converted, err := convertValue(position, value, typ, o)
+	if err != nil {
+		return err
+	}
+
+	out.Set(converted)
+	return nil
+}
+
+// convertStruct converts the map value found in position to a struct of type typ,
+// it is used by convertValue for structs.
+func convertStruct(position string, value interface{}, typ reflect.Type, o options) (reflect.Value, error) {
+	if reflect.ValueOf(value).Kind() != reflect.Map {
+		return reflect.Value{}, mismatch(position, typ.String(), value, nil)
+	}
+
+	out := reflect.New(typ).Elem()
+	if errs := fillStruct(position, value, out, o); len(errs) > 0 {
+		return reflect.Value{}, &DecodeError{Errors: errs}
+	}
+	return out, nil
+}
+
+// fillStruct sets the fields of struct out with the keys of map value,
+// the fields of embedded structs are filled from the same map.
+func fillStruct(position string, value interface{}, out reflect.Value, o options) []error {
+	var errs []error
+
+	typ := out.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		tag := field.Tag.Get(o.tagName())
+		if tag == "-" {
+			continue
+		}
+		name, flags := parseTag(tag)
+
+		if field.Anonymous && name == "" {
+			if field.Type.Kind() == reflect.Struct {
+				errs = append(errs, fillStruct(position, value, out.Field(i), o)...)
+				continue
+			}
+			if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct && field.PkgPath == "" {
+				if out.Field(i).IsNil() {
+					out.Field(i).Set(reflect.New(field.Type.Elem()))
+				}
+				errs = append(errs, fillStruct(position, value, out.Field(i).Elem(), o)...)
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldValue, ok := structField(value, name)
+		if !ok || fieldValue == nil {
+			continue
+		}
+
+		fieldOptions := o
+		if layout := field.Tag.Get("layout"); layout != "" {
+			fieldOptions.layout = layout
+		}
+		if hasFlag(flags, "string") {
+			fieldOptions.lenient = true
+		}
+
+		if err := decodeInto(keyPosition(position, name), fieldValue, out.Field(i), fieldOptions); err != nil {
+			errs = appendErrors(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// structField returns the value of key name in the map value,
+// if there is no exact match the keys are compared case-insensitively.
+func structField(value interface{}, name string) (interface{}, bool) {
+	if v, ok := child(value, segment{key: name}); ok {
+		return v, true
+	}
+
+	iter := reflect.ValueOf(value).MapRange()
+	for iter.Next() {
+		if strings.EqualFold(fmt.Sprint(iter.Key().Interface()), name) {
+			return iter.Value().Interface(), true
+		}
+	}
+	return nil, false
+}
+
+// parseTag splits the tag in the name and the flags after the first comma.
+func parseTag(tag string) (string, string) {
+	if i := strings.IndexByte(tag, ','); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
+
+// hasFlag returns true if flag is one of the flags separated by comma.
+func hasFlag(flags, flag string) bool {
+	for _, f := range strings.Split(flags, ",") {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// appendErrors appends err to errs, the errors of a *DecodeError are appended one by one.
+func appendErrors(errs []error, err error) []error {
+	if decodeErr, ok := err.(*DecodeError); ok {
+		return append(errs, decodeErr.Errors...)
+	}
+	return append(errs, err)
+}
+
+// Decode is helper for function Decode from Map.
+func Decode(position string, mapper map[string]interface{}, out interface{}, opts ...Option) error {
+	return New(mapper).Decode(position, out, opts...)
+}
@@ -0,0 +1,205 @@
+package nested
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type decodeContact struct {
+	Name   string   `json:"name"`
+	Phones []string `json:"phones"`
+}
+
+type decodeBase struct {
+	ID string `json:"id"`
+}
+
+type decodeAdvert struct {
+	decodeBase
+	Title    string            `json:"title"`
+	Price    float32           `json:"price"`
+	Rates    []uint8           `json:"rates"`
+	Contact  *decodeContact    `json:"contact"`
+	Status   map[string]string `json:"-"`
+	Created  time.Time         `json:"created"`
+	Birth    time.Time         `json:"birth" layout:"02/01/2006"`
+	Level    int               `json:"level,string"`
+	Nickname string
+	ignored  string
+}
+
+func TestDecode(t *testing.T) {
+	mapper := map[string]interface{}{
+		"advert": map[string]interface{}{
+			"id":       "12",
+			"title":    "Lorem Ipsum",
+			"price":    19.9,
+			"rates":    []interface{}{float64(5), 4},
+			"contact":  map[string]interface{}{"name": "daniel3", "phones": []interface{}{"473-68-42"}},
+			"status":   map[string]interface{}{"code": "active"},
+			"created":  "1987-01-29T19:00:00Z",
+			"birth":    "29/01/1987",
+			"level":    "3",
+			"nickname": "dan",
+			"ignored":  "ignored",
+		},
+	}
+
+	var advert decodeAdvert
+	if err := Decode("advert", mapper, &advert); err != nil {
+		t.Fatalf("Expected error nil, but got %s", err)
+	}
+
+	expected := decodeAdvert{
+		decodeBase: decodeBase{ID: "12"},
+		Title:      "Lorem Ipsum",
+		Price:      19.9,
+		Rates:      []uint8{5, 4},
+		Contact:    &decodeContact{Name: "daniel3", Phones: []string{"473-68-42"}},
+		Created:    time.Date(1987, 1, 29, 19, 0, 0, 0, time.UTC),
+		Birth:      time.Date(1987, 1, 29, 0, 0, 0, 0, time.UTC),
+		Level:      3,
+		Nickname:   "dan",
+	}
+	if !reflect.DeepEqual(expected, advert) {
+		t.Errorf("Expected %+v, but got %+v", expected, advert)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	mapper := map[string]interface{}{
+		"advert": map[string]interface{}{
+			"title":   12,
+			"rates":   []interface{}{5, 400},
+			"contact": map[string]interface{}{"name": "daniel3", "phones": "473-68-42"},
+			"created": "29/01/1987",
+		},
+	}
+
+	var advert decodeAdvert
+	err := Decode("advert", mapper, &advert)
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("Expected a *DecodeError, but got %v", err)
+	}
+
+	paths := make([]string, len(decodeErr.Errors))
+	for i, err := range decodeErr.Errors {
+		switch e := err.(type) {
+		case *TypeMismatchError:
+			paths[i] = e.Path
+		case *ParseError:
+			paths[i] = e.Path
+		default:
+			t.Errorf("Expected a *TypeMismatchError or *ParseError, but got %T", err)
+		}
+	}
+
+	expected := []string{"advert.title", "advert.rates.1", "advert.contact.phones", "advert.created"}
+	if !reflect.DeepEqual(expected, paths) {
+		t.Errorf("Expected errors in %v, but got %v", expected, paths)
+	}
+
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected error %v in the errors, but got %v", ErrOverflow, err)
+	}
+
+	// without the Unwrap() []error of Go 1.20 the errors of fields are found by Is and As.
+	if !decodeErr.Is(ErrOverflow) || decodeErr.Is(ErrInvalidPosition) {
+		t.Errorf("Expected Is to find only %v, but got %v", ErrOverflow, err)
+	}
+
+	var parseErr *ParseError
+	if !decodeErr.As(&parseErr) || parseErr.Path != "advert.created" {
+		t.Errorf("Expected As to find the *ParseError of advert.created, but got %v", parseErr)
+	}
+}
+
+func TestDecodeOptions(t *testing.T) {
+	type person struct {
+		Name  string `yaml:"full_name"`
+		Level int    `yaml:"level"`
+	}
+
+	mapper := New(map[string]interface{}{"full_name": "Rodrigo", "level": "3"})
+
+	var out person
+	if err := mapper.Decode("", &out, TagName("yaml"), Lenient()); err != nil {
+		t.Fatalf("Expected error nil, but got %s", err)
+	}
+	if out.Name != "Rodrigo" || out.Level != 3 {
+		t.Errorf("Expected Rodrigo and 3, but got %+v", out)
+	}
+
+	if err := mapper.Decode("", out); !errors.Is(err, ErrInvalidInputType) {
+		t.Errorf("Expected error %v, but got %v", ErrInvalidInputType, err)
+	}
+
+	if err := mapper.Decode("bananas", &out); err == nil {
+		t.Errorf("Expected a *PathNotFoundError, but got nil")
+	}
+}
+func TestDecodeKeepsFields(t *testing.T) {
+	type inner struct {
+		A int
+		B int
+	}
+	type contact struct {
+		Name  string `json:"name"`
+		Phone string `json:"phone"`
+		Inner *inner `json:"inner"`
+		Value inner  `json:"value"`
+		Dash  int    `json:"-,"`
+		Skip  int    `json:"-"`
+	}
+
+	mapper := New(map[string]interface{}{
+		"name":  "a",
+		"inner": map[string]interface{}{"A": 9},
+		"value": map[string]interface{}{"B": 8},
+		"-":     5,
+		"Skip":  6,
+	})
+
+	keep := &inner{A: 1, B: 2}
+	out := contact{Phone: "keep", Inner: keep, Value: inner{A: 3, B: 4}, Skip: 7}
+	if err := mapper.Decode("", &out); err != nil {
+		t.Fatalf("Expected error nil, but got %s", err)
+	}
+
+	expected := contact{Name: "a", Phone: "keep", Inner: &inner{A: 9, B: 2}, Value: inner{A: 3, B: 8}, Dash: 5, Skip: 7}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %+v, but got %+v", expected, out)
+	}
+	if out.Inner != keep {
+		t.Errorf("Expected the pointer %p to be reused, but got %p", keep, out.Inner)
+	}
+
+	var nested struct {
+		S *inner `json:"s"`
+	}
+	nested.S = &inner{A: 1, B: 2}
+	if err := Decode("", map[string]interface{}{"s": map[string]interface{}{"A": 9}}, &nested); err != nil {
+		t.Fatalf("Expected error nil, but got %s", err)
+	}
+	if *nested.S != (inner{A: 9, B: 2}) {
+		t.Errorf("Expected {9 2}, but got %+v", *nested.S)
+	}
+}
+
+func ExampleMap_Decode() {
+	data, _ := NewFromJSON(`{"advert": {"contact": {"name": "daniel3", "phones": ["473-68-42", "789-52-84"]}}}`)
+
+	var contact struct {
+		Name   string   `json:"name"`
+		Phones []string `json:"phones"`
+	}
+
+	err := data.Decode("advert.contact", &contact)
+	fmt.Println(contact.Name, contact.Phones, err)
+	// output: daniel3 [473-68-42 789-52-84] <nil>
+}
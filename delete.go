@@ -0,0 +1,122 @@
+package nested
+
+import "reflect"
+
+// Has returns true if there is a value in the position that you pass separately by . (dot),
+// even when the value is nil or a type that the getters don't recognise.
+func (m Map) Has(position string) bool {
+	_, missing := walk(map[string]interface{}(m), splitPosition(position), false)
+	return missing < 0
+}
+
+// Delete removes the value in the position that you pass separately by . (dot),
+// indexes remove the element from the slice. It returns false if the position is not found.
+func (m Map) Delete(position string) bool {
+	return m.delete(position, false)
+}
+
+// DeleteAndPrune removes the value like Delete and after that
+// removes the maps and slices in the position that become empty.
+func (m Map) DeleteAndPrune(position string) bool {
+	return m.delete(position, true)
+}
+
+func (m Map) delete(position string, prune bool) bool {
+	pos := splitPosition(position)
+	if len(pos) == 0 {
+		return false
+	}
+
+	_, ok := remove(map[string]interface{}(m), pos, prune)
+	return ok
+}
+
+// remove deletes the value in pos from node, it returns the node changed,
+// slices are copied without the element so the parent must store the returned node.
+func remove(node interface{}, pos []segment, prune bool) (interface{}, bool) {
+	if len(pos) == 1 {
+		return removeChild(node, pos[0])
+	}
+
+	v, ok := child(node, pos[0])
+	if !ok {
+		return node, false
+	}
+
+	if v, ok = remove(v, pos[1:], prune); !ok {
+		return node, false
+	}
+
+	if prune && isEmpty(v) {
+		return removeChild(node, pos[0])
+	}
+
+	if err := assign(node, pos[:1], v); err != nil {
+		return node, false
+	}
+
+	return node, true
+}
+
+// removeChild deletes the key or index seg from node.
+func removeChild(node interface{}, seg segment) (interface{}, bool) {
+	if n, ok := node.(map[string]interface{}); ok {
+		if _, ok := n[seg.key]; !ok {
+			return node, false
+		}
+		delete(n, seg.key)
+		return n, true
+	}
+
+	rv := reflect.ValueOf(node)
+	switch rv.Kind() {
+	case reflect.Map:
+		key, ok := mapKey(rv, seg.key)
+		if !ok || !rv.MapIndex(key).IsValid() {
+			return node, false
+		}
+		rv.SetMapIndex(key, reflect.Value{})
+		return node, true
+	case reflect.Slice:
+		index, ok := sliceIndex(seg, rv.Len())
+		if !ok {
+			return node, false
+		}
+
+		out := reflect.MakeSlice(rv.Type(), 0, rv.Len()-1)
+		out = reflect.AppendSlice(out, rv.Slice(0, index))
+		out = reflect.AppendSlice(out, rv.Slice(index+1, rv.Len()))
+		return out.Interface(), true
+	}
+
+	return node, false
+}
+
+// isEmpty returns true if value is a map or a slice without elements.
+func isEmpty(value interface{}) bool {
+	if n, ok := value.(map[string]interface{}); ok {
+		return len(n) == 0
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice:
+		return rv.Len() == 0
+	}
+	return false
+}
+
+// Has is helper for function Has from Map.
+func Has(position string, mapper map[string]interface{}) bool {
+	return New(mapper).Has(position)
+}
+
+// Delete is helper for function Delete from Map.
+func Delete(position string, mapper map[string]interface{}) bool {
+	return New(mapper).Delete(position)
+}
+
+// DeleteAndPrune is helper for function DeleteAndPrune from Map.
+func DeleteAndPrune(position string, mapper map[string]interface{}) bool {
+	return New(mapper).DeleteAndPrune(position)
+}
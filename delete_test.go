@@ -0,0 +1,220 @@
+package nested
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestHas(t *testing.T) {
+	mapper := map[string]interface{}{
+		"person": map[string]interface{}{
+			"name":     "Rodrigo",
+			"active":   true,
+			"nickname": nil,
+			"phones":   []string{"473-68-42"},
+		},
+	}
+
+	tests := []struct {
+		Parameter string
+		Expected  bool
+	}{
+		{Parameter: "person", Expected: true},
+		{Parameter: "person.name", Expected: true},
+		{Parameter: "person.active", Expected: true},
+		{Parameter: "person.nickname", Expected: true},
+		{Parameter: "person.phones", Expected: true},
+		{Parameter: "person.phones[0]", Expected: true},
+		{Parameter: "person.phones[1]", Expected: false},
+		{Parameter: "person.name.first", Expected: false},
+		{Parameter: "person.bananas", Expected: false},
+		{Parameter: "person[0", Expected: false},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			if actual := Has(test.Parameter, mapper); actual != test.Expected {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.Expected, actual)
+			}
+		})
+	}
+}
+func ExampleMap_Has() {
+	data := map[string]interface{}{
+		"person": map[string]interface{}{
+			"nickname": nil,
+		},
+	}
+
+	fmt.Println(New(data).Has("person.nickname"), New(data).Has("person.name"))
+	// output: true false
+}
+
+func TestDelete(t *testing.T) {
+	newData := func() map[string]interface{} {
+		return map[string]interface{}{
+			"person": map[string]interface{}{
+				"name":   "Rodrigo",
+				"phones": []string{"473-68-42", "789-52-84"},
+				"images": []interface{}{
+					map[string]interface{}{"url": "www.loremipsum.com/1.jpg"},
+				},
+			},
+			"session": map[string]interface{}{
+				"token": "62vsy29v8y4v248v5y97v1e21v35ce97",
+			},
+		}
+	}
+
+	tests := []struct {
+		Parameter      string
+		Prune          bool
+		ExpectedFirst  bool
+		ExpectedSecond map[string]interface{}
+	}{
+		{
+			Parameter:     "person.name",
+			ExpectedFirst: true,
+			ExpectedSecond: map[string]interface{}{
+				"person": map[string]interface{}{
+					"phones": []string{"473-68-42", "789-52-84"},
+					"images": []interface{}{
+						map[string]interface{}{"url": "www.loremipsum.com/1.jpg"},
+					},
+				},
+				"session": map[string]interface{}{
+					"token": "62vsy29v8y4v248v5y97v1e21v35ce97",
+				},
+			},
+		},
+		{
+			Parameter:     "person.phones.0",
+			ExpectedFirst: true,
+			ExpectedSecond: map[string]interface{}{
+				"person": map[string]interface{}{
+					"name":   "Rodrigo",
+					"phones": []string{"789-52-84"},
+					"images": []interface{}{
+						map[string]interface{}{"url": "www.loremipsum.com/1.jpg"},
+					},
+				},
+				"session": map[string]interface{}{
+					"token": "62vsy29v8y4v248v5y97v1e21v35ce97",
+				},
+			},
+		},
+		{
+			Parameter:     "session.token",
+			ExpectedFirst: true,
+			ExpectedSecond: map[string]interface{}{
+				"person": map[string]interface{}{
+					"name":   "Rodrigo",
+					"phones": []string{"473-68-42", "789-52-84"},
+					"images": []interface{}{
+						map[string]interface{}{"url": "www.loremipsum.com/1.jpg"},
+					},
+				},
+				"session": map[string]interface{}{},
+			},
+		},
+		{
+			Parameter:     "session.token",
+			Prune:         true,
+			ExpectedFirst: true,
+			ExpectedSecond: map[string]interface{}{
+				"person": map[string]interface{}{
+					"name":   "Rodrigo",
+					"phones": []string{"473-68-42", "789-52-84"},
+					"images": []interface{}{
+						map[string]interface{}{"url": "www.loremipsum.com/1.jpg"},
+					},
+				},
+			},
+		},
+		{
+			Parameter:     "person.images[0].url",
+			Prune:         true,
+			ExpectedFirst: true,
+			ExpectedSecond: map[string]interface{}{
+				"person": map[string]interface{}{
+					"name":   "Rodrigo",
+					"phones": []string{"473-68-42", "789-52-84"},
+				},
+				"session": map[string]interface{}{
+					"token": "62vsy29v8y4v248v5y97v1e21v35ce97",
+				},
+			},
+		},
+		{
+			Parameter:      "person.bananas",
+			ExpectedFirst:  false,
+			ExpectedSecond: newData(),
+		},
+		{
+			Parameter:      "person.phones.2",
+			ExpectedFirst:  false,
+			ExpectedSecond: newData(),
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			mapper := newData()
+
+			var actual bool
+			if test.Prune {
+				actual = DeleteAndPrune(test.Parameter, mapper)
+			} else {
+				actual = Delete(test.Parameter, mapper)
+			}
+
+			if actual != test.ExpectedFirst || !reflect.DeepEqual(test.ExpectedSecond, mapper) {
+				t.Errorf("[%s] expected param1: %v and map: %v, but got param1: %v and map: %v",
+					test.Parameter,
+					test.ExpectedFirst, test.ExpectedSecond,
+					actual, mapper,
+				)
+			}
+		})
+	}
+}
+func TestDeleteMapKinds(t *testing.T) {
+	mapper := map[string]interface{}{
+		"labels": map[string]string{"app": "nested"},
+		"yaml":   map[interface{}]interface{}{1: map[interface{}]interface{}{"name": "Rodrigo"}},
+	}
+
+	if !DeleteAndPrune("yaml.1.name", mapper) || !Delete("labels.app", mapper) {
+		t.Fatalf("Expected to delete the positions, but got %v", mapper)
+	}
+
+	expected := map[string]interface{}{
+		"labels": map[string]string{},
+	}
+	if !reflect.DeepEqual(expected, mapper) {
+		t.Errorf("Expected %v, but got %v", expected, mapper)
+	}
+}
+func ExampleMap_Delete() {
+	data := New(map[string]interface{}{
+		"session": map[string]interface{}{
+			"token": "62vsy29v8y4v248v5y97v1e21v35ce97",
+		},
+	})
+
+	deleted := data.Delete("session.token")
+	fmt.Println(data, deleted)
+	// output: map[session:map[]] true
+}
+func ExampleMap_DeleteAndPrune() {
+	data := New(map[string]interface{}{
+		"session": map[string]interface{}{
+			"token": "62vsy29v8y4v248v5y97v1e21v35ce97",
+		},
+	})
+
+	deleted := data.DeleteAndPrune("session.token")
+	fmt.Println(data, deleted)
+	// output: map[] true
+}
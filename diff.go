@@ -0,0 +1,211 @@
+package nested
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// ChangeKind is the kind of a Change found by Diff.
+type ChangeKind int
+
+const (
+	// Added when the position exists only in the second Map.
+	Added ChangeKind = iota
+	// Removed when the position exists only in the first Map.
+	Removed
+	// Modified when the position has different values of the same type in both maps.
+	Modified
+	// TypeChanged when the position has values of different types in both maps, like a string and a map.
+	TypeChanged
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Modified:
+		return "modified"
+	case TypeChanged:
+		return "type-changed"
+	}
+	return "unknown"
+}
+
+// Change is a difference found by Diff, Path has the keys and the indexes of slices separately by . (dot),
+// Old is the value in the first Map and New is the value in the second Map.
+type Change struct {
+	Path string
+	Kind ChangeKind
+	Old  interface{}
+	New  interface{}
+}
+
+// IgnorePaths makes Diff skip the positions and everything inside of them,
+// the segment * matches any key or index like items.*.updated_at.
+func IgnorePaths(positions ...string) Option {
+	return func(o *options) {
+		o.ignore = append(o.ignore, positions...)
+	}
+}
+
+// NumericEqual makes Diff compare the numbers by value, so int 1 and float64 1 are equal.
+func NumericEqual() Option {
+	return func(o *options) {
+		o.numericEqual = true
+	}
+}
+
+// Diff returns the changes from a to b going through the nested maps and slices like Interface,
+// the changes are sorted by the keys of maps and the indexes of slices.
+func Diff(a, b Map, opts ...Option) []Change {
+	d := differ{o: newOptions(opts)}
+	for _, position := range d.o.ignore {
+		if pos := splitPosition(position); pos != nil {
+			d.ignore = append(d.ignore, pos)
+		}
+	}
+
+	d.diffMaps("", nil, map[string]interface{}(a), map[string]interface{}(b))
+	return d.changes
+}
+
+// differ keeps the changes found by Diff.
+type differ struct {
+	o       options
+	ignore  [][]segment
+	changes []Change
+}
+
+// diff compares a and b in position, keys are the keys of position used to ignore paths.
+func (d *differ) diff(position string, keys []string, a, b interface{}) {
+	if d.ignored(keys) {
+		return
+	}
+
+	kindA, kindB := valueKind(a), valueKind(b)
+	switch {
+	case kindA != kindB:
+		d.changes = append(d.changes, Change{Path: position, Kind: TypeChanged, Old: a, New: b})
+	case kindA == "map":
+		d.diffMaps(position, keys, a, b)
+	case kindA == "slice":
+		d.diffSlices(position, keys, a, b)
+	case !d.equal(a, b):
+		d.changes = append(d.changes, Change{Path: position, Kind: Modified, Old: a, New: b})
+	}
+}
+
+// diffMaps compares the keys of maps a and b.
+func (d *differ) diffMaps(position string, keys []string, a, b interface{}) {
+	itemsA, itemsB := children(a), children(b)
+
+	valuesB := make(map[string]interface{}, len(itemsB))
+	for _, item := range itemsB {
+		valuesB[item.Path] = item.Value
+	}
+
+	names := make([]string, 0, len(itemsA)+len(itemsB))
+	valuesA := make(map[string]interface{}, len(itemsA))
+	for _, item := range itemsA {
+		valuesA[item.Path] = item.Value
+		names = append(names, item.Path)
+	}
+	for _, item := range itemsB {
+		if _, ok := valuesA[item.Path]; !ok {
+			names = append(names, item.Path)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		childKeys := append(keys[:len(keys):len(keys)], name)
+		childPosition := keyPosition(position, name)
+
+		valueA, inA := valuesA[name]
+		valueB, inB := valuesB[name]
+		switch {
+		case !inB:
+			d.add(childKeys, Change{Path: childPosition, Kind: Removed, Old: valueA})
+		case !inA:
+			d.add(childKeys, Change{Path: childPosition, Kind: Added, New: valueB})
+		default:
+			d.diff(childPosition, childKeys, valueA, valueB)
+		}
+	}
+}
+
+// diffSlices compares the elements of slices a and b with the same index.
+func (d *differ) diffSlices(position string, keys []string, a, b interface{}) {
+	itemsA, _ := toSlice(a)
+	itemsB, _ := toSlice(b)
+
+	for i := 0; i < len(itemsA) || i < len(itemsB); i++ {
+		childPosition := elementPosition(position, i)
+		childKeys := append(keys[:len(keys):len(keys)], strconv.Itoa(i))
+
+		switch {
+		case i >= len(itemsB):
+			d.add(childKeys, Change{Path: childPosition, Kind: Removed, Old: itemsA[i]})
+		case i >= len(itemsA):
+			d.add(childKeys, Change{Path: childPosition, Kind: Added, New: itemsB[i]})
+		default:
+			d.diff(childPosition, childKeys, itemsA[i], itemsB[i])
+		}
+	}
+}
+
+// add appends change if its position is not ignored.
+func (d *differ) add(keys []string, change Change) {
+	if !d.ignored(keys) {
+		d.changes = append(d.changes, change)
+	}
+}
+
+// ignored returns true if keys are inside of some position of IgnorePaths.
+func (d *differ) ignored(keys []string) bool {
+	for _, pos := range d.ignore {
+		if len(pos) > len(keys) {
+			continue
+		}
+
+		match := true
+		for i, seg := range pos {
+			if !seg.wildcard && seg.key != keys[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// equal compares the values a and b that are not maps or slices,
+// numbers are compared by value with NumericEqual.
+func (d *differ) equal(a, b interface{}) bool {
+	if d.o.numericEqual && valueKind(a) == "number" {
+		return numbersEqual(a, b)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// numbersEqual returns true if the numbers a and b have the same value,
+// integers are compared without converting them to float64.
+func numbersEqual(a, b interface{}) bool {
+	o := options{}
+
+	intA, errA := toInt64(a, o)
+	intB, errB := toInt64(b, o)
+	if errA == nil && errB == nil {
+		return intA == intB
+	}
+
+	floatA, errA := toFloat64(a, o)
+	floatB, errB := toFloat64(b, o)
+	return errA == nil && errB == nil && floatA == floatB
+}
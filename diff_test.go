@@ -0,0 +1,104 @@
+package nested
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	before := Map{
+		"id":      "12",
+		"price":   10,
+		"tags":    []interface{}{"a", "b", "c"},
+		"contact": map[string]interface{}{"name": "daniel", "phones": []string{"111"}},
+		"status":  map[string]interface{}{"active": true, "updated_at": "2020-01-01"},
+		"labels":  map[string]string{"k8s.io/name": "api"},
+		"extra":   nil,
+	}
+	after := Map{
+		"id":      "12",
+		"price":   10.0,
+		"tags":    []interface{}{"a", "x"},
+		"contact": map[string]interface{}{"name": "rod", "phones": []string{"111", "222"}, "email": "rod@example.com"},
+		"status":  "inactive",
+		"labels":  map[string]string{"k8s.io/name": "web"},
+		"extra":   nil,
+	}
+
+	tests := []struct {
+		Options  []Option
+		Expected []Change
+	}{
+		{
+			Expected: []Change{
+				{Path: "contact.email", Kind: Added, New: "rod@example.com"},
+				{Path: "contact.name", Kind: Modified, Old: "daniel", New: "rod"},
+				{Path: "contact.phones.1", Kind: Added, New: "222"},
+				{Path: `labels.k8s\.io/name`, Kind: Modified, Old: "api", New: "web"},
+				{Path: "price", Kind: Modified, Old: 10, New: 10.0},
+				{Path: "status", Kind: TypeChanged, Old: before["status"], New: "inactive"},
+				{Path: "tags.1", Kind: Modified, Old: "b", New: "x"},
+				{Path: "tags.2", Kind: Removed, Old: "c"},
+			},
+		},
+		{
+			Options: []Option{NumericEqual(), IgnorePaths("contact", "status", `labels.k8s\.io/name`)},
+			Expected: []Change{
+				{Path: "tags.1", Kind: Modified, Old: "b", New: "x"},
+				{Path: "tags.2", Kind: Removed, Old: "c"},
+			},
+		},
+		{
+			Options:  []Option{NumericEqual(), IgnorePaths("*.phones", "contact.*", "tags.*", "labels", "status")},
+			Expected: nil,
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual := Diff(before, after, test.Options...)
+			if !reflect.DeepEqual(test.Expected, actual) {
+				t.Errorf("expected %v, but got %v", test.Expected, actual)
+			}
+		})
+	}
+
+	if changes := Diff(before, before); changes != nil {
+		t.Errorf("expected no changes, but got %v", changes)
+	}
+}
+
+func TestNumbersEqual(t *testing.T) {
+	tests := []struct {
+		A, B     interface{}
+		Expected bool
+	}{
+		{A: 1, B: 1.0, Expected: true},
+		{A: int64(9007199254740993), B: uint64(9007199254740993), Expected: true},
+		{A: int64(9007199254740993), B: float64(9007199254740992), Expected: false},
+		{A: 1.5, B: float32(1.5), Expected: true},
+		{A: 2, B: 1.5, Expected: false},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			if actual := numbersEqual(test.A, test.B); actual != test.Expected {
+				t.Errorf("[%v %v] expected %v, but got %v", test.A, test.B, test.Expected, actual)
+			}
+		})
+	}
+}
+
+func ExampleDiff() {
+	before, _ := NewFromJSON(`{"name": "daniel", "phones": ["111"], "level": 1}`)
+	after, _ := NewFromJSON(`{"name": "rod", "phones": ["111", "222"]}`)
+
+	for _, change := range Diff(before, after) {
+		fmt.Println(change.Kind, change.Path, change.Old, change.New)
+	}
+	// output:
+	// removed level 1 <nil>
+	// modified name daniel rod
+	// added phones.1 <nil> 222
+}
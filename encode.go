@@ -0,0 +1,239 @@
+package nested
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NewFromStruct returns new Map instance from a struct, a pointer to struct or any map with keys that can be printed.
+// The structs become map[string]interface{} using the keys named by the tag json, or the tag defined by the option TagName,
+// the tag option omitempty skips empty values and the fields of embedded structs are promoted like encoding/json does.
+// The maps become map[string]interface{}, slices and arrays become []interface{}, pointers are dereferenced,
+// time.Time, []byte and the other values are kept with their types.
+// It returns ErrInvalidInputType if in is not a struct or a map, or if in has a cycle like a struct that points to itself.
+func NewFromStruct(in interface{}, opts ...Option) (Map, error) {
+	enc := encoder{o: newOptions(opts)}
+
+	value, err := enc.encodeValue(reflect.ValueOf(in))
+	if err != nil {
+		return nil, err
+	}
+
+	out, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidInputType
+	}
+	return New(out), nil
+}
+
+// encoder converts values to Map, visiting has the pointers, maps and slices
+// that are being encoded to find cycles.
+type encoder struct {
+	o        options
+	visiting map[visit]struct{}
+}
+
+// visit is a pointer, map or slice being encoded.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// enter marks rv as being encoded, it returns an error if rv is already being encoded because it has a cycle.
+func (enc *encoder) enter(rv reflect.Value) (visit, error) {
+	v := visit{ptr: rv.Pointer(), typ: rv.Type()}
+	if rv.Kind() == reflect.Slice {
+		v.len = rv.Len()
+	}
+
+	if _, ok := enc.visiting[v]; ok {
+		return v, fmt.Errorf("%w: cycle found in %s", ErrInvalidInputType, rv.Type())
+	}
+	if enc.visiting == nil {
+		enc.visiting = make(map[visit]struct{})
+	}
+	enc.visiting[v] = struct{}{}
+	return v, nil
+}
+
+// encodeValue converts rv to the values that Map can navigate.
+func (enc *encoder) encodeValue(rv reflect.Value) (interface{}, error) {
+	if !rv.IsValid() {
+		return nil, nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Kind() == reflect.Interface {
+			return enc.encodeValue(rv.Elem())
+		}
+
+		v, err := enc.enter(rv)
+		if err != nil {
+			return nil, err
+		}
+		defer delete(enc.visiting, v)
+
+		return enc.encodeValue(rv.Elem())
+	case reflect.Struct:
+		if rv.Type() == timeType {
+			return rv.Interface(), nil
+		}
+
+		out := make(map[string]interface{}, rv.NumField())
+		if err := enc.encodeStruct(rv, out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	case reflect.Map:
+		v, err := enc.enter(rv)
+		if err != nil {
+			return nil, err
+		}
+		defer delete(enc.visiting, v)
+
+		out := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			value, err := enc.encodeValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			out[fmt.Sprint(iter.Key().Interface())] = value
+		}
+		return out, nil
+	case reflect.Slice:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Interface(), nil
+		}
+
+		v, err := enc.enter(rv)
+		if err != nil {
+			return nil, err
+		}
+		defer delete(enc.visiting, v)
+
+		return enc.encodeElements(rv)
+	case reflect.Array:
+		return enc.encodeElements(rv)
+	}
+
+	return rv.Interface(), nil
+}
+
+// encodeElements converts the elements of slice or array rv to []interface{}.
+func (enc *encoder) encodeElements(rv reflect.Value) (interface{}, error) {
+	out := make([]interface{}, rv.Len())
+	for i := range out {
+		value, err := enc.encodeValue(rv.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = value
+	}
+	return out, nil
+}
+
+// encodeStruct stores the fields of struct rv in out, the fields of embedded structs
+// are stored first so the fields of rv have priority over them.
+func (enc *encoder) encodeStruct(rv reflect.Value, out map[string]interface{}) error {
+	typ := rv.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+
+		name, _ := parseTag(field.Tag.Get(enc.o.tagName()))
+		if name != "" {
+			continue
+		}
+
+		// like encoding/json the embedded pointers to unexported struct types are used too,
+		// their exported fields are promoted.
+		embedded := rv.Field(i)
+		if embedded.Kind() == reflect.Ptr {
+			if embedded.IsNil() {
+				continue
+			}
+
+			v, err := enc.enter(embedded)
+			if err != nil {
+				return err
+			}
+			defer delete(enc.visiting, v)
+
+			embedded = embedded.Elem()
+		}
+
+		if embedded.Kind() == reflect.Struct {
+			if err := enc.encodeStruct(embedded, out); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		tag := field.Tag.Get(enc.o.tagName())
+		if tag == "-" {
+			continue
+		}
+		name, flags := parseTag(tag)
+
+		if field.Anonymous && name == "" && isStructType(field.Type) {
+			continue
+		}
+		if field.PkgPath != "" && !(field.Anonymous && isStructType(field.Type)) {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		value := rv.Field(i)
+		if hasFlag(flags, "omitempty") && isEmptyValue(value) {
+			continue
+		}
+
+		encoded, err := enc.encodeValue(value)
+		if err != nil {
+			return err
+		}
+		out[name] = encoded
+	}
+
+	return nil
+}
+
+// isEmptyValue returns true for the values skipped by the tag option omitempty.
+func isEmptyValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return rv.IsNil()
+	case reflect.Struct:
+		return false
+	}
+
+	return rv.IsZero()
+}
+
+// isStructType returns true if typ is a struct or a pointer to struct.
+func isStructType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct
+}
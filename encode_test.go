@@ -0,0 +1,211 @@
+package nested
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type encodeStatus string
+
+type encodeBase struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type encodeContact struct {
+	Name   string   `json:"name"`
+	Phones []string `json:"phones,omitempty"`
+}
+
+type encodeAdvert struct {
+	encodeBase
+	*encodeContact `json:"contact"`
+	Title          string            `json:"title"`
+	Status         encodeStatus      `json:"status"`
+	Price          float64           `json:"price,omitempty"`
+	Labels         map[string]string `json:"labels"`
+	Created        time.Time         `json:"created"`
+	Owner          *encodeContact    `json:"owner"`
+	Ignored        string            `json:"-"`
+	Nickname       string
+	secret         string
+}
+
+func TestNewFromStruct(t *testing.T) {
+	created := time.Date(1987, 1, 29, 19, 0, 0, 0, time.UTC)
+	advert := &encodeAdvert{
+		encodeBase:    encodeBase{ID: "12", Title: "Base"},
+		encodeContact: &encodeContact{Name: "daniel3"},
+		Title:         "Lorem Ipsum",
+		Status:        "active",
+		Labels:        map[string]string{"app": "nested"},
+		Created:       created,
+		Ignored:       "ignored",
+		Nickname:      "dan",
+		secret:        "secret",
+	}
+
+	out, err := NewFromStruct(advert)
+	if err != nil {
+		t.Fatalf("Expected error nil, but got %s", err)
+	}
+
+	expected := Map{
+		"id":       "12",
+		"title":    "Lorem Ipsum",
+		"contact":  map[string]interface{}{"name": "daniel3"},
+		"status":   encodeStatus("active"),
+		"labels":   map[string]interface{}{"app": "nested"},
+		"created":  created,
+		"owner":    nil,
+		"Nickname": "dan",
+	}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %v, but got %v", expected, out)
+	}
+
+	if name := out.GetString("contact.name"); name != "daniel3" {
+		t.Errorf("Expected contact.name daniel3, but got %v", name)
+	}
+	if status := out.GetString("status"); status != "active" {
+		t.Errorf("Expected status active, but got %v", status)
+	}
+}
+
+func TestNewFromStructTypes(t *testing.T) {
+	tests := []struct {
+		Input          interface{}
+		ExpectedFirst  Map
+		ExpectedSecond error
+	}{
+		{
+			Input:         map[string]string{"app": "nested"},
+			ExpectedFirst: Map{"app": "nested"},
+		},
+		{
+			Input:         map[int][]int{1: {1, 2}},
+			ExpectedFirst: Map{"1": []interface{}{1, 2}},
+		},
+		{
+			Input:         map[string]interface{}{"list": [2]encodeContact{{Name: "a"}, {Name: "b", Phones: []string{"1"}}}},
+			ExpectedFirst: Map{"list": []interface{}{map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b", "phones": []interface{}{"1"}}}},
+		},
+		{
+			Input:          []string{"nested"},
+			ExpectedSecond: ErrInvalidInputType,
+		},
+		{
+			Input:          nil,
+			ExpectedSecond: ErrInvalidInputType,
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, err := NewFromStruct(test.Input)
+			if !reflect.DeepEqual(test.ExpectedFirst, actual) || err != test.ExpectedSecond {
+				t.Errorf("[%T] expected %v and error %v, but got %v and error %v",
+					test.Input, test.ExpectedFirst, test.ExpectedSecond, actual, err)
+			}
+		})
+	}
+}
+
+type encodePromoted struct {
+	X int
+	y int
+}
+
+type encodeEmbedded struct {
+	*encodePromoted
+	Z    int
+	Dash int `json:"-,"`
+	Skip int `json:"-"`
+}
+
+func TestNewFromStructLikeJSON(t *testing.T) {
+	in := encodeEmbedded{encodePromoted: &encodePromoted{X: 1, y: 3}, Z: 2, Dash: 4, Skip: 5}
+
+	out, err := NewFromStruct(in)
+	if err != nil {
+		t.Fatalf("Expected error nil, but got %s", err)
+	}
+
+	b, _ := json.Marshal(in)
+	expected, _ := NewFromJSON(string(b))
+	if !reflect.DeepEqual(map[string]interface{}{"X": 1.0, "Z": 2.0, "-": 4.0}, map[string]interface{}(expected)) {
+		t.Fatalf("Expected encoding/json to give X, Z and -, but got %s", b)
+	}
+
+	if changes := Diff(expected, out, NumericEqual()); len(changes) != 0 {
+		t.Errorf("Expected %v like encoding/json, but got %v", expected, out)
+	}
+
+	if out, _ := NewFromStruct(encodeEmbedded{Z: 2}); !reflect.DeepEqual(Map{"Z": 2, "-": 0}, out) {
+		t.Errorf("Expected the nil embedded pointer to be skipped, but got %v", out)
+	}
+}
+
+type encodeNode struct {
+	Name string
+	Next *encodeNode
+}
+
+func TestNewFromStructCycle(t *testing.T) {
+	node := &encodeNode{Name: "a"}
+	node.Next = node
+
+	loop := map[string]interface{}{}
+	loop["self"] = loop
+
+	list := []interface{}{1}
+	list[0] = list
+
+	for key, in := range []interface{}{node, loop, Map{"list": list}} {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			if _, err := NewFromStruct(in); !errors.Is(err, ErrInvalidInputType) {
+				t.Errorf("Expected ErrInvalidInputType for a cycle, but got %v", err)
+			}
+		})
+	}
+
+	// the same pointer twice without a cycle is not an error.
+	shared := &encodeNode{Name: "b"}
+	out, err := NewFromStruct(struct{ A, B *encodeNode }{A: shared, B: shared})
+	if err != nil {
+		t.Fatalf("Expected error nil, but got %s", err)
+	}
+
+	expected := Map{"A": map[string]interface{}{"Name": "b", "Next": nil}, "B": map[string]interface{}{"Name": "b", "Next": nil}}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %v, but got %v", expected, out)
+	}
+}
+
+func TestNewFromInterfaceStruct(t *testing.T) {
+	type person struct {
+		Name string `yaml:"full_name"`
+	}
+
+	out, err := NewFromInterface(person{Name: "Rodrigo"}, TagName("yaml"))
+	if err != nil || out.GetString("full_name") != "Rodrigo" {
+		t.Errorf("Expected full_name Rodrigo and error nil, but got %v and error %v", out, err)
+	}
+}
+func ExampleNewFromStruct() {
+	type contact struct {
+		Name   string   `json:"name"`
+		Phones []string `json:"phones,omitempty"`
+	}
+
+	data, _ := NewFromStruct(struct {
+		Contact contact `json:"contact"`
+	}{Contact: contact{Name: "daniel3", Phones: []string{"473-68-42"}}})
+
+	fmt.Println(data.GetString("contact.phones.0"))
+	// output: 473-68-42
+}
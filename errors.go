@@ -0,0 +1,181 @@
+package nested
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidPosition when position cannot be parsed.
+var ErrInvalidPosition = errors.New("this is not a valid position")
+
+// SyntaxError when position cannot be parsed, Column is where the error was found starting from 1.
+// It is also ErrInvalidPosition for errors.Is.
+type SyntaxError struct {
+	Position string
+	Column   int
+	Msg      string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid position %q at column %d: %s", e.Position, e.Column, e.Msg)
+}
+
+// Is returns true for ErrInvalidPosition.
+func (e *SyntaxError) Is(target error) bool {
+	return target == ErrInvalidPosition
+}
+
+// syntaxError returns *SyntaxError for position with the column of offset.
+func syntaxError(position string, offset int, msg string) error {
+	return &SyntaxError{Position: position, Column: offset + 1, Msg: msg}
+}
+
+// NotContainerError when a segment in the middle of position holds a value that is not a map or a slice,
+// so it is not possible to go deeper in this position.
+type NotContainerError struct {
+	Path  string
+	Value interface{}
+}
+
+func (e *NotContainerError) Error() string {
+	return fmt.Sprintf("the position %q holds a %T that cannot have nested values", e.Path, e.Value)
+}
+
+// IndexError when a segment of position is an index out of range of the slice.
+type IndexError struct {
+	Path   string
+	Index  int
+	Length int
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("the index %d is out of range in position %q with length %d", e.Index, e.Path, e.Length)
+}
+
+// PathNotFoundError when a segment of position is not found.
+type PathNotFoundError struct {
+	Path           string
+	MissingSegment string
+}
+
+func (e *PathNotFoundError) Error() string {
+	return fmt.Sprintf("the position %q is not found, missing segment %q", e.Path, e.MissingSegment)
+}
+
+// TypeMismatchError when the value in position is not the type requested,
+// Err has the reason when the value cannot be converted, like ErrOverflow or ErrFractional.
+type TypeMismatchError struct {
+	Path string
+	Want string
+	Got  string
+	Err  error
+}
+
+func (e *TypeMismatchError) Error() string {
+	msg := fmt.Sprintf("the position %q is %s, not %s", e.Path, e.Got, e.Want)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the reason why the value cannot be converted.
+func (e *TypeMismatchError) Unwrap() error {
+	return e.Err
+}
+
+// ConflictError when Merge finds different values in the same position of both maps
+// and the strategy is ConflictFail.
+type ConflictError struct {
+	Path     string
+	Existing interface{}
+	Incoming interface{}
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("the position %q has conflicting values %v and %v", e.Path, e.Existing, e.Incoming)
+}
+
+// PatchError when an operation of ApplyPatch fails, Index is the index of the operation in the patch.
+type PatchError struct {
+	Index int
+	Op    Operation
+	Err   error
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("the operation %d (%s %q) failed: %s", e.Index, e.Op.Op, e.Op.Path, e.Err)
+}
+
+// Unwrap returns the error of the operation.
+func (e *PatchError) Unwrap() error {
+	return e.Err
+}
+
+// Violation is a rule of validation that the value in Path doesn't follow,
+// Rule is the name of rule like required, type or maxLength.
+type Violation struct {
+	Path    string
+	Rule    string
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// ValidationError when the Map doesn't follow the validation, it has all violations found.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.String()
+	}
+	return fmt.Sprintf("the validation failed with %d violations: %s", len(e.Violations), strings.Join(messages, "; "))
+}
+
+// ParseError when the value in position cannot be parsed with the layout,
+// Layout is a time layout or the format like "json".
+type ParseError struct {
+	Path   string
+	Layout string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("cannot parse input as %s: %s", e.Layout, e.Err)
+	}
+	return fmt.Sprintf("cannot parse position %q with layout %q: %s", e.Path, e.Layout, e.Err)
+}
+
+// Unwrap returns the error of parser.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true for ErrInvalidInputType, a value that cannot be parsed is an invalid input.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrInvalidInputType
+}
+
+// mismatch returns *TypeMismatchError for value in position that is not the type want.
+func mismatch(position, want string, value interface{}, err error) error {
+	if err == ErrInvalidInputType {
+		err = nil
+	}
+
+	got := "nil"
+	if value != nil {
+		got = fmt.Sprintf("%T", value)
+	}
+
+	return &TypeMismatchError{Path: position, Want: want, Got: got, Err: err}
+}
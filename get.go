@@ -0,0 +1,58 @@
+package nested
+
+import (
+	"reflect"
+)
+
+// Get returns the value from position that you passed by argument converted to the type T and a bool if found the field.
+// T can be any built-in scalar type, time.Time, Map, slices, maps and pointers of them,
+// the values are converted like the getters do, like Int for numbers and Time for time.Time.
+// if it doesn't find the field or the value cannot be converted the returns is the zero value of T and false.
+func Get[T any](m Map, position string, opts ...Option) (T, bool) {
+	value, err := GetE[T](m, position, opts...)
+	return value, err == nil
+}
+
+// GetOr returns the value from position converted to the type T like Get,
+// if it doesn't find the field or the value cannot be converted it returns def.
+func GetOr[T any](m Map, position string, def T, opts ...Option) T {
+	value, err := GetE[T](m, position, opts...)
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// GetE returns the value from position converted to the type T like Get,
+// it returns *PathNotFoundError, *TypeMismatchError or *ParseError when it fails.
+func GetE[T any](m Map, position string, opts ...Option) (T, error) {
+	valueTmp, err := m.InterfaceE(position, opts...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return convertTo[T](position, valueTmp, opts)
+}
+
+// Convert returns value converted to the type T like Get does for the value in position,
+// position is only used as the path of *TypeMismatchError or *ParseError when it fails.
+func Convert[T any](position string, value interface{}, opts ...Option) (T, error) {
+	return convertTo[T](position, value, opts)
+}
+
+// convertTo returns valueTmp converted to the type T, values that are already T are returned without conversion.
+func convertTo[T any](position string, valueTmp interface{}, opts []Option) (T, error) {
+	var zero T
+
+	if value, ok := valueTmp.(T); ok {
+		return value, nil
+	}
+
+	value, err := convertValue(position, valueTmp, reflect.TypeOf(&zero).Elem(), newOptions(opts))
+	if err != nil {
+		return zero, err
+	}
+	out, _ := value.Interface().(T)
+	return out, nil
+}
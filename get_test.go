@@ -0,0 +1,210 @@
+package nested
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	mapper := New(map[string]interface{}{
+		"person": map[string]interface{}{
+			"name":    "Rodrigo",
+			"level":   float64(3),
+			"weight":  80.5,
+			"active":  true,
+			"created": "2018-08-08T18:00:00Z",
+			"birth":   "29/01/1987",
+			"tags":    []interface{}{"new", "sale"},
+			"scores":  map[string]interface{}{"math": float64(9), "art": 7},
+			"nothing": nil,
+		},
+	})
+
+	tests := []struct {
+		Name           string
+		Get            func() (interface{}, bool)
+		ExpectedFirst  interface{}
+		ExpectedSecond bool
+	}{
+		{
+			Name:           "string",
+			Get:            func() (interface{}, bool) { return Get[string](mapper, "person.name") },
+			ExpectedFirst:  "Rodrigo",
+			ExpectedSecond: true,
+		},
+		{
+			Name:           "int from float64",
+			Get:            func() (interface{}, bool) { return Get[int](mapper, "person.level") },
+			ExpectedFirst:  3,
+			ExpectedSecond: true,
+		},
+		{
+			Name:           "uint16",
+			Get:            func() (interface{}, bool) { return Get[uint16](mapper, "person.level") },
+			ExpectedFirst:  uint16(3),
+			ExpectedSecond: true,
+		},
+		{
+			Name:           "int8 overflow",
+			Get:            func() (interface{}, bool) { return Get[int8](mapper, "person.weight") },
+			ExpectedFirst:  int8(0),
+			ExpectedSecond: false,
+		},
+		{
+			Name:           "float32",
+			Get:            func() (interface{}, bool) { return Get[float32](mapper, "person.weight") },
+			ExpectedFirst:  float32(80.5),
+			ExpectedSecond: true,
+		},
+		{
+			Name:           "bool",
+			Get:            func() (interface{}, bool) { return Get[bool](mapper, "person.active") },
+			ExpectedFirst:  true,
+			ExpectedSecond: true,
+		},
+		{
+			Name:           "time.Time",
+			Get:            func() (interface{}, bool) { return Get[time.Time](mapper, "person.created") },
+			ExpectedFirst:  time.Date(2018, 8, 8, 18, 0, 0, 0, time.UTC),
+			ExpectedSecond: true,
+		},
+		{
+			Name:           "time.Time with layout",
+			Get:            func() (interface{}, bool) { return Get[time.Time](mapper, "person.birth", Layout("02/01/2006")) },
+			ExpectedFirst:  time.Date(1987, 1, 29, 0, 0, 0, 0, time.UTC),
+			ExpectedSecond: true,
+		},
+		{
+			Name:           "[]string",
+			Get:            func() (interface{}, bool) { return Get[[]string](mapper, "person.tags") },
+			ExpectedFirst:  []string{"new", "sale"},
+			ExpectedSecond: true,
+		},
+		{
+			Name:           "map[string]int",
+			Get:            func() (interface{}, bool) { return Get[map[string]int](mapper, "person.scores") },
+			ExpectedFirst:  map[string]int{"math": 9, "art": 7},
+			ExpectedSecond: true,
+		},
+		{
+			Name: "Map",
+			Get:  func() (interface{}, bool) { return Get[Map](mapper, "person.scores") },
+			ExpectedFirst: Map{
+				"math": float64(9),
+				"art":  7,
+			},
+			ExpectedSecond: true,
+		},
+		{
+			Name:           "interface{} nil",
+			Get:            func() (interface{}, bool) { return Get[interface{}](mapper, "person.nothing") },
+			ExpectedFirst:  nil,
+			ExpectedSecond: true,
+		},
+		{
+			Name:           "string mismatch",
+			Get:            func() (interface{}, bool) { return Get[string](mapper, "person.level") },
+			ExpectedFirst:  "",
+			ExpectedSecond: false,
+		},
+		{
+			Name:           "not found",
+			Get:            func() (interface{}, bool) { return Get[string](mapper, "person.bananas") },
+			ExpectedFirst:  "",
+			ExpectedSecond: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			actual, result := test.Get()
+			if !reflect.DeepEqual(test.ExpectedFirst, actual) || result != test.ExpectedSecond {
+				t.Errorf("[%s] expected param1: %T(%v) and param2: %v, but got param1: %T(%v) and param2: %v",
+					test.Name, test.ExpectedFirst, test.ExpectedFirst, test.ExpectedSecond, actual, actual, result)
+			}
+		})
+	}
+
+	t.Run("pointer", func(t *testing.T) {
+		actual, ok := Get[*int](mapper, "person.level")
+		if !ok || actual == nil || *actual != 3 {
+			t.Errorf("Expected a pointer to 3, but got %v and %v", actual, ok)
+		}
+	})
+}
+
+func TestGetE(t *testing.T) {
+	mapper := New(map[string]interface{}{
+		"scores": map[string]interface{}{"math": 9, "art": "seven"},
+	})
+
+	_, err := GetE[map[string]int](mapper, "scores")
+
+	var mismatch *TypeMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Path != "scores.art" || mismatch.Want != "int" {
+		t.Errorf("Expected a *TypeMismatchError in scores.art, but got %v", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	if value, err := Convert[int64]("level", 3.0); err != nil || value != 3 {
+		t.Errorf("Expected 3, but got %v %v", value, err)
+	}
+
+	var mismatch *TypeMismatchError
+	if _, err := Convert[[]int]("$['scores']", []interface{}{1, "two"}); !errors.As(err, &mismatch) || mismatch.Path != "$['scores'].1" {
+		t.Errorf("Expected a *TypeMismatchError in $['scores'].1, but got %v", err)
+	}
+}
+
+func TestGetOr(t *testing.T) {
+	mapper := New(map[string]interface{}{"level": 3})
+
+	if actual := GetOr(mapper, "level", 1); actual != 3 {
+		t.Errorf("Expected 3, but got %v", actual)
+	}
+	if actual := GetOr(mapper, "bananas", 1); actual != 1 {
+		t.Errorf("Expected 1, but got %v", actual)
+	}
+	if actual := GetOr(mapper, "level", "unknown"); actual != "unknown" {
+		t.Errorf("Expected unknown, but got %v", actual)
+	}
+}
+func ExampleGet() {
+	data, _ := NewFromJSON(`{"person": {"level": 3, "tags": ["new", "sale"]}}`)
+
+	level, found := Get[int](data, "person.level")
+	fmt.Println(level, found)
+
+	tags, found := Get[[]string](data, "person.tags")
+	fmt.Println(tags, found)
+	// output:
+	// 3 true
+	// [new sale] true
+}
+func ExampleGetOr() {
+	data := New(map[string]interface{}{})
+
+	level := GetOr(data, "person.level", 1)
+	fmt.Println(level)
+	// output: 1
+}
+func BenchmarkGet(b *testing.B) {
+	total := 10
+
+	bench := make([]Map, total)
+	for i := 0; i < total; i++ {
+		bench[i] = randomData()
+	}
+
+	for n := 0; n < total; n++ {
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Get[int](bench[n], "advert.status.ttl")
+			}
+		})
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/rodkranz/nested
 
-go 1.13
+go 1.18
 
 require (
 	github.com/corpix/uarand v0.1.1 // indirect
@@ -0,0 +1,541 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rodkranz/nested"
+)
+
+// context has the root value of query and the current value (@) of a filter.
+type context struct {
+	root    interface{}
+	current interface{}
+}
+
+// query is a list of segments starting from the root ($) or from the current value (@).
+type query struct {
+	relative bool
+	segments []segment
+}
+
+// nodes returns the nodes found by the query, path is the normalized path of start value.
+func (q *query) nodes(ctx *context, path string) Nodes {
+	start := ctx.root
+	if q.relative {
+		start = ctx.current
+	}
+
+	nodes := Nodes{{Path: path, Value: start}}
+	for _, seg := range q.segments {
+		var next Nodes
+		for _, n := range nodes {
+			next = seg.apply(ctx, n, next)
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// singular returns true if the query finds at most one node, it has only names and indexes.
+func (q *query) singular() bool {
+	for _, seg := range q.segments {
+		if seg.descendant || len(seg.selectors) != 1 {
+			return false
+		}
+		switch seg.selectors[0].(type) {
+		case nameSelector, indexSelector:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// segment is a child segment ([...] or .name) or a descendant segment (..).
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+// apply appends to out the nodes selected from n.
+func (s segment) apply(ctx *context, n Node, out Nodes) Nodes {
+	if !s.descendant {
+		for _, sel := range s.selectors {
+			out = sel.apply(ctx, n, out)
+		}
+		return out
+	}
+
+	for _, d := range descendants(n, Nodes{n}) {
+		for _, sel := range s.selectors {
+			out = sel.apply(ctx, d, out)
+		}
+	}
+	return out
+}
+
+// descendants appends n's children recursively to out, each node is followed by its children.
+func descendants(n Node, out Nodes) Nodes {
+	for _, c := range children(n) {
+		out = descendants(c, append(out, c))
+	}
+	return out
+}
+
+// selector selects nodes from the children of a node.
+type selector interface {
+	apply(ctx *context, n Node, out Nodes) Nodes
+}
+
+// nameSelector selects the member name of an object.
+type nameSelector string
+
+func (s nameSelector) apply(_ *context, n Node, out Nodes) Nodes {
+	if v, ok := member(n.Value, string(s)); ok {
+		out = append(out, Node{Path: n.Path + "[" + quote(string(s)) + "]", Value: v})
+	}
+	return out
+}
+
+// wildcardSelector selects all members of an object or all elements of an array.
+type wildcardSelector struct{}
+
+func (wildcardSelector) apply(_ *context, n Node, out Nodes) Nodes {
+	return append(out, children(n)...)
+}
+
+// indexSelector selects the element of an array, negative indexes are counted from the end.
+type indexSelector int
+
+func (s indexSelector) apply(_ *context, n Node, out Nodes) Nodes {
+	rv, ok := array(n.Value)
+	if !ok {
+		return out
+	}
+
+	index := int(s)
+	if index < 0 {
+		index += rv.Len()
+	}
+	if index < 0 || index >= rv.Len() {
+		return out
+	}
+
+	return append(out, element(n, rv, index))
+}
+
+// sliceSelector selects the elements of an array from start to end (exclusive) by step.
+type sliceSelector struct {
+	start, end, step *int
+}
+
+func (s sliceSelector) apply(_ *context, n Node, out Nodes) Nodes {
+	rv, ok := array(n.Value)
+	if !ok {
+		return out
+	}
+
+	step := 1
+	if s.step != nil {
+		step = *s.step
+	}
+	if step == 0 {
+		return out
+	}
+
+	length := rv.Len()
+	normalize := func(i int) int {
+		if i < 0 {
+			return length + i
+		}
+		return i
+	}
+
+	var start, end int
+	if step > 0 {
+		start, end = 0, length
+	} else {
+		start, end = length-1, -1
+	}
+	if s.start != nil {
+		start = normalize(*s.start)
+	}
+	if s.end != nil {
+		end = normalize(*s.end)
+	}
+
+	if step > 0 {
+		lower, upper := clamp(start, 0, length), clamp(end, 0, length)
+		for i := lower; i < upper; i += step {
+			out = append(out, element(n, rv, i))
+		}
+		return out
+	}
+
+	upper, lower := clamp(start, -1, length-1), clamp(end, -1, length-1)
+	for i := upper; lower < i; i += step {
+		out = append(out, element(n, rv, i))
+	}
+	return out
+}
+
+// clamp returns i limited between min and max.
+func clamp(i, min, max int) int {
+	if i < min {
+		return min
+	}
+	if i > max {
+		return max
+	}
+	return i
+}
+
+// filterSelector selects the children of a node where the expression is true.
+type filterSelector struct {
+	expr logical
+}
+
+func (s filterSelector) apply(ctx *context, n Node, out Nodes) Nodes {
+	for _, c := range children(n) {
+		if s.expr.test(&context{root: ctx.root, current: c.Value}) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// logical is an expression of a filter that is true or false.
+type logical interface {
+	test(ctx *context) bool
+}
+
+// orExpr is true if any expression is true.
+type orExpr []logical
+
+func (e orExpr) test(ctx *context) bool {
+	for _, expr := range e {
+		if expr.test(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+// andExpr is true if all expressions are true.
+type andExpr []logical
+
+func (e andExpr) test(ctx *context) bool {
+	for _, expr := range e {
+		if !expr.test(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+// notExpr negates the expression.
+type notExpr struct {
+	expr logical
+}
+
+func (e notExpr) test(ctx *context) bool {
+	return !e.expr.test(ctx)
+}
+
+// existsExpr is true if the query finds some node.
+type existsExpr struct {
+	query *query
+}
+
+func (e existsExpr) test(ctx *context) bool {
+	return len(e.query.nodes(ctx, "")) > 0
+}
+
+// comparisonExpr compares two values with the operator ==, !=, <, <=, > or >=.
+type comparisonExpr struct {
+	left, right operand
+	op          string
+}
+
+func (e comparisonExpr) test(ctx *context) bool {
+	left, leftOK := e.left.value(ctx)
+	right, rightOK := e.right.value(ctx)
+
+	switch e.op {
+	case "==":
+		return equal(left, leftOK, right, rightOK)
+	case "!=":
+		return !equal(left, leftOK, right, rightOK)
+	case "<":
+		return less(left, leftOK, right, rightOK)
+	case "<=":
+		return less(left, leftOK, right, rightOK) || equal(left, leftOK, right, rightOK)
+	case ">":
+		return less(right, rightOK, left, leftOK)
+	case ">=":
+		return less(right, rightOK, left, leftOK) || equal(left, leftOK, right, rightOK)
+	}
+	return false
+}
+
+// operand is a value used in comparisons, the bool is false when there is no value (Nothing).
+type operand interface {
+	value(ctx *context) (interface{}, bool)
+}
+
+// literal is a string, number, true, false or null of a filter.
+type literal struct {
+	v interface{}
+}
+
+func (l literal) value(*context) (interface{}, bool) {
+	return l.v, true
+}
+
+// singularQuery is the value of a query that finds at most one node.
+type singularQuery struct {
+	query *query
+}
+
+func (s singularQuery) value(ctx *context) (interface{}, bool) {
+	nodes := s.query.nodes(ctx, "")
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0].Value, true
+}
+
+// equal compares a and b like RFC 9535, numbers are compared by value and
+// objects and arrays are compared member by member.
+func equal(a interface{}, aOK bool, b interface{}, bOK bool) bool {
+	if !aOK || !bOK {
+		return aOK == bOK
+	}
+
+	if isNumber(a) && isNumber(b) {
+		return compareNumbers(a, b) == 0
+	}
+
+	if ra, ok := array(a); ok {
+		rb, ok := array(b)
+		if !ok || ra.Len() != rb.Len() {
+			return false
+		}
+		for i := 0; i < ra.Len(); i++ {
+			if !equal(ra.Index(i).Interface(), true, rb.Index(i).Interface(), true) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if isObject(a) {
+		if !isObject(b) {
+			return false
+		}
+		ma, mb := members(a), members(b)
+		if len(ma) != len(mb) {
+			return false
+		}
+		for i := range ma {
+			if ma[i].Path != mb[i].Path || !equal(ma[i].Value, true, mb[i].Value, true) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if isObject(b) {
+		return false
+	}
+	if _, ok := array(b); ok {
+		return false
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// less returns true if a is less than b, only numbers and strings can be compared.
+func less(a interface{}, aOK bool, b interface{}, bOK bool) bool {
+	if !aOK || !bOK {
+		return false
+	}
+
+	if isNumber(a) && isNumber(b) {
+		return compareNumbers(a, b) < 0
+	}
+
+	sa, ok := a.(string)
+	if !ok {
+		return false
+	}
+	sb, ok := b.(string)
+	if !ok {
+		return false
+	}
+	return sa < sb
+}
+
+// compareNumbers returns -1, 0 or 1 comparing a and b, integers are compared without losing precision.
+func compareNumbers(a, b interface{}) int {
+	ia, errA := nested.ToInt64(a)
+	ib, errB := nested.ToInt64(b)
+	if errA == nil && errB == nil {
+		switch {
+		case ia < ib:
+			return -1
+		case ia > ib:
+			return 1
+		}
+		return 0
+	}
+
+	fa, _ := nested.ToFloat64(a)
+	fb, _ := nested.ToFloat64(b)
+	switch {
+	case fa < fb:
+		return -1
+	case fa > fb:
+		return 1
+	}
+	return 0
+}
+
+// isNumber returns true if value is any integer or float type or json.Number.
+func isNumber(value interface{}) bool {
+	if _, ok := value.(json.Number); ok {
+		return true
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// isObject returns true if value is a map.
+func isObject(value interface{}) bool {
+	return reflect.ValueOf(value).Kind() == reflect.Map
+}
+
+// array returns value as reflect.Value if it is a slice or an array.
+func array(value interface{}) (reflect.Value, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		return rv, true
+	}
+	return reflect.Value{}, false
+}
+
+// member returns the value of key name in the object value.
+func member(value interface{}, name string) (interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		v, ok := m[name]
+		return v, ok
+	case nested.Map:
+		v, ok := m[name]
+		return v, ok
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Map {
+		return nil, false
+	}
+
+	if rv.Type().Key().Kind() == reflect.String {
+		v := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+		if !v.IsValid() {
+			return nil, false
+		}
+		return v.Interface(), true
+	}
+
+	for _, m := range members(value) {
+		if m.Path == name {
+			return m.Value, true
+		}
+	}
+	return nil, false
+}
+
+// members returns the members of object value sorted by key, Path is the key.
+func members(value interface{}) []Node {
+	rv := reflect.ValueOf(value)
+
+	out := make([]Node, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		out = append(out, Node{Path: fmt.Sprint(iter.Key().Interface()), Value: iter.Value().Interface()})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out
+}
+
+// children returns the members of an object or the elements of an array with their normalized paths.
+func children(n Node) Nodes {
+	if rv, ok := array(n.Value); ok {
+		out := make(Nodes, rv.Len())
+		for i := range out {
+			out[i] = element(n, rv, i)
+		}
+		return out
+	}
+
+	if !isObject(n.Value) {
+		return nil
+	}
+
+	items := members(n.Value)
+	out := make(Nodes, len(items))
+	for i, m := range items {
+		out[i] = Node{Path: n.Path + "[" + quote(m.Path) + "]", Value: m.Value}
+	}
+	return out
+}
+
+// element returns the node of element index of array rv in n.
+func element(n Node, rv reflect.Value, index int) Node {
+	return Node{Path: n.Path + "[" + strconv.Itoa(index) + "]", Value: rv.Index(index).Interface()}
+}
+
+// quote returns name between single quotes escaped like the normalized paths of RFC 9535.
+func quote(name string) string {
+	var b strings.Builder
+	b.WriteByte('\'')
+	for _, r := range name {
+		switch r {
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '\\':
+			b.WriteString(`\\`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
@@ -0,0 +1,131 @@
+package jsonpath
+
+import (
+	"reflect"
+	"regexp"
+	"unicode/utf8"
+)
+
+// kind is the type of a function parameter or of the function result.
+type kind int
+
+const (
+	valueKind kind = iota
+	logicalKind
+	nodesKind
+)
+
+// function is a function extension of RFC 9535 that can be called in filters.
+// Arguments of valueKind are passed as value and ok, arguments of nodesKind are passed as Nodes.
+type function struct {
+	params []kind
+	result kind
+	call   func(args []argument) (interface{}, bool)
+}
+
+// argument is the evaluated argument of a function call.
+type argument struct {
+	value interface{}
+	ok    bool
+	nodes Nodes
+}
+
+// functions are the functions defined by RFC 9535.
+var functions = map[string]*function{
+	"length": {params: []kind{valueKind}, result: valueKind, call: length},
+	"count":  {params: []kind{nodesKind}, result: valueKind, call: count},
+	"match":  {params: []kind{valueKind, valueKind}, result: logicalKind, call: match},
+	"search": {params: []kind{valueKind, valueKind}, result: logicalKind, call: search},
+	"value":  {params: []kind{nodesKind}, result: valueKind, call: value},
+}
+
+// functionExpr is a function call of a filter, it is an operand when the result is a value
+// and a logical expression when the result is logical.
+type functionExpr struct {
+	fn   *function
+	args []interface{}
+}
+
+func (e functionExpr) value(ctx *context) (interface{}, bool) {
+	args := make([]argument, len(e.args))
+	for i, arg := range e.args {
+		switch a := arg.(type) {
+		case operand:
+			args[i].value, args[i].ok = a.value(ctx)
+		case *query:
+			args[i].nodes = a.nodes(ctx, "")
+		}
+	}
+	return e.fn.call(args)
+}
+
+func (e functionExpr) test(ctx *context) bool {
+	v, ok := e.value(ctx)
+	b, _ := v.(bool)
+	return ok && b
+}
+
+// length returns the number of characters of a string, elements of an array or members of an object.
+func length(args []argument) (interface{}, bool) {
+	if !args[0].ok {
+		return nil, false
+	}
+
+	if s, ok := args[0].value.(string); ok {
+		return utf8.RuneCountInString(s), true
+	}
+
+	rv := reflect.ValueOf(args[0].value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len(), true
+	}
+	return nil, false
+}
+
+// count returns the number of nodes.
+func count(args []argument) (interface{}, bool) {
+	return len(args[0].nodes), true
+}
+
+// match returns true if the string matches the whole regular expression.
+func match(args []argument) (interface{}, bool) {
+	return matchString(args, true), true
+}
+
+// search returns true if some substring of the string matches the regular expression.
+func search(args []argument) (interface{}, bool) {
+	return matchString(args, false), true
+}
+
+// matchString returns true if the first argument matches the regular expression of second argument,
+// it returns false if some argument is not a string or the regular expression is not valid.
+func matchString(args []argument, full bool) bool {
+	s, ok := args[0].value.(string)
+	if !ok || !args[0].ok {
+		return false
+	}
+
+	expr, ok := args[1].value.(string)
+	if !ok || !args[1].ok {
+		return false
+	}
+
+	if full {
+		expr = "^(?:" + expr + ")$"
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(s)
+}
+
+// value returns the value of the node if there is only one node.
+func value(args []argument) (interface{}, bool) {
+	if len(args[0].nodes) != 1 {
+		return nil, false
+	}
+	return args[0].nodes[0].Value, true
+}
@@ -0,0 +1,124 @@
+// Package jsonpath implements the JSONPath query language of RFC 9535 for nested.Map values.
+//
+// A query starts with $ and is followed by segments like .name, ['name'], [0], [1:5:2], [*],
+// [?@.price < 10] and the descendant segment .., for example:
+//
+//	nodes, err := jsonpath.Query(m, `$.store.book[?@.price < 10].title`)
+//
+// Each Node has the normalized path of the value, like $['store']['book'][0], and the value
+// can be read with the typed getters of nested using Get or Node.Map.
+package jsonpath
+
+import (
+	"fmt"
+
+	"github.com/rodkranz/nested"
+)
+
+// Node is a value found by a query with its normalized path, like $['store']['book'][0].
+type Node struct {
+	Path  string
+	Value interface{}
+}
+
+// Map returns the value of node as nested.Map so the getters of nested can be used on it,
+// it returns false if the value is not a map.
+func (n Node) Map() (nested.Map, bool) {
+	m, err := nested.NewFromInterface(n.Value)
+	if err != nil {
+		return nil, false
+	}
+	return m, true
+}
+
+// Nodes is the list of nodes found by a query.
+type Nodes []Node
+
+// Values returns the values of nodes.
+func (ns Nodes) Values() []interface{} {
+	values := make([]interface{}, len(ns))
+	for i, n := range ns {
+		values[i] = n.Value
+	}
+	return values
+}
+
+// Paths returns the normalized paths of nodes.
+func (ns Nodes) Paths() []string {
+	paths := make([]string, len(ns))
+	for i, n := range ns {
+		paths[i] = n.Path
+	}
+	return paths
+}
+
+// Get returns the value of node converted to the type T like nested.Get does.
+func Get[T any](n Node, opts ...nested.Option) (T, bool) {
+	value, err := GetE[T](n, opts...)
+	return value, err == nil
+}
+
+// GetE returns the value of node converted to the type T like Get,
+// it returns *nested.TypeMismatchError or *nested.ParseError with the path of node when it fails.
+func GetE[T any](n Node, opts ...nested.Option) (T, error) {
+	return nested.Convert[T](n.Path, n.Value, opts...)
+}
+
+// Path is a compiled JSONPath query that can be used many times.
+type Path struct {
+	expr  string
+	query *query
+}
+
+// Compile parses expr as a JSONPath query,
+// it returns *SyntaxError if expr is not a valid query.
+func Compile(expr string) (*Path, error) {
+	p := &parser{expr: expr}
+
+	q, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Path{expr: expr, query: q}, nil
+}
+
+// MustCompile is like Compile but panics if expr is not a valid query.
+func MustCompile(expr string) *Path {
+	p, err := Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the expression of the query.
+func (p *Path) String() string {
+	return p.expr
+}
+
+// Query returns the nodes found by the query in m.
+func (p *Path) Query(m nested.Map) Nodes {
+	root := map[string]interface{}(m)
+	return p.query.nodes(&context{root: root, current: root}, "$")
+}
+
+// Query compiles expr and returns the nodes found in m.
+func Query(m nested.Map, expr string) (Nodes, error) {
+	p, err := Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return p.Query(m), nil
+}
+
+// SyntaxError when a query is not valid, Offset is the position of expr where the error was found.
+type SyntaxError struct {
+	Expr   string
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid jsonpath %q at offset %d: %s", e.Expr, e.Offset, e.Msg)
+}
@@ -0,0 +1,350 @@
+package jsonpath
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/rodkranz/nested"
+)
+
+const store = `{
+	"store": {
+		"book": [
+			{"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+			{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+			{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+			{"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 399}
+	}
+}`
+
+func TestQuery(t *testing.T) {
+	data, err := nested.NewFromJSON(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Parameter string
+		Expected  []string
+	}{
+		{
+			Parameter: "$.store.book[*].author",
+			Expected: []string{
+				"$['store']['book'][0]['author']",
+				"$['store']['book'][1]['author']",
+				"$['store']['book'][2]['author']",
+				"$['store']['book'][3]['author']",
+			},
+		},
+		{
+			Parameter: "$..author",
+			Expected: []string{
+				"$['store']['book'][0]['author']",
+				"$['store']['book'][1]['author']",
+				"$['store']['book'][2]['author']",
+				"$['store']['book'][3]['author']",
+			},
+		},
+		{
+			Parameter: "$.store.*",
+			Expected:  []string{"$['store']['bicycle']", "$['store']['book']"},
+		},
+		{
+			Parameter: "$.store..price",
+			Expected: []string{
+				"$['store']['bicycle']['price']",
+				"$['store']['book'][0]['price']",
+				"$['store']['book'][1]['price']",
+				"$['store']['book'][2]['price']",
+				"$['store']['book'][3]['price']",
+			},
+		},
+		{
+			Parameter: "$..book[2]",
+			Expected:  []string{"$['store']['book'][2]"},
+		},
+		{
+			Parameter: "$..book[-1]",
+			Expected:  []string{"$['store']['book'][3]"},
+		},
+		{
+			Parameter: "$..book[0,1]",
+			Expected:  []string{"$['store']['book'][0]", "$['store']['book'][1]"},
+		},
+		{
+			Parameter: "$..book[:2]",
+			Expected:  []string{"$['store']['book'][0]", "$['store']['book'][1]"},
+		},
+		{
+			Parameter: "$..book[1:4:2]",
+			Expected:  []string{"$['store']['book'][1]", "$['store']['book'][3]"},
+		},
+		{
+			Parameter: "$..book[::-1]",
+			Expected: []string{
+				"$['store']['book'][3]",
+				"$['store']['book'][2]",
+				"$['store']['book'][1]",
+				"$['store']['book'][0]",
+			},
+		},
+		{
+			Parameter: "$..book[0:4:0]",
+			Expected:  nil,
+		},
+		{
+			Parameter: "$..book[?@.isbn]",
+			Expected:  []string{"$['store']['book'][2]", "$['store']['book'][3]"},
+		},
+		{
+			Parameter: "$..book[?(@.price < 10)]",
+			Expected:  []string{"$['store']['book'][0]", "$['store']['book'][2]"},
+		},
+		{
+			Parameter: "$..book[?@.price == 8.95 || @.author == 'Herman Melville']",
+			Expected:  []string{"$['store']['book'][0]", "$['store']['book'][2]"},
+		},
+		{
+			Parameter: "$..book[?@.category == 'fiction' && !(@.price > 10)]",
+			Expected:  []string{"$['store']['book'][2]"},
+		},
+		{
+			Parameter: "$..book[?@.price > $.store.bicycle.price]",
+			Expected:  nil,
+		},
+		{
+			Parameter: "$..book[?match(@.author, 'J.*')]",
+			Expected:  []string{"$['store']['book'][3]"},
+		},
+		{
+			Parameter: "$..book[?search(@.title, 'of')]",
+			Expected:  []string{"$['store']['book'][0]", "$['store']['book'][1]", "$['store']['book'][3]"},
+		},
+		{
+			Parameter: "$..book[?length(@.title) > 15]",
+			Expected:  []string{"$['store']['book'][0]", "$['store']['book'][3]"},
+		},
+		{
+			Parameter: "$.store[?count(@.*) == 2]",
+			Expected:  []string{"$['store']['bicycle']"},
+		},
+		{
+			Parameter: "$.store.book[?value(@..isbn) == '0-553-21311-3']",
+			Expected:  []string{"$['store']['book'][2]"},
+		},
+		{
+			Parameter: "$.store.book[?@.missing == @.other]",
+			Expected: []string{
+				"$['store']['book'][0]",
+				"$['store']['book'][1]",
+				"$['store']['book'][2]",
+				"$['store']['book'][3]",
+			},
+		},
+		{
+			Parameter: `$["store"]['bicycle']["color"]`,
+			Expected:  []string{"$['store']['bicycle']['color']"},
+		},
+		{
+			Parameter: "$.store.bicycle.color.name",
+			Expected:  nil,
+		},
+		{
+			Parameter: "$",
+			Expected:  []string{"$"},
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			nodes, err := Query(data, test.Parameter)
+			if err != nil {
+				t.Fatalf("[%s] unexpected error %v", test.Parameter, err)
+			}
+
+			var actual []string
+			if len(nodes) > 0 {
+				actual = nodes.Paths()
+			}
+			if !reflect.DeepEqual(test.Expected, actual) {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestQueryValues(t *testing.T) {
+	data := nested.Map{
+		"a": []interface{}{3, 5, 1, 2, 4, 6, map[string]interface{}{"b": "j"}, map[string]interface{}{"b": "k"}, []interface{}{}, nil, false},
+		"o": map[string]string{"j b": "x", "it's": "y"},
+	}
+
+	tests := []struct {
+		Parameter string
+		Expected  []interface{}
+	}{
+		{Parameter: "$.a[?@ > 3]", Expected: []interface{}{5, 4, 6}},
+		{Parameter: "$.a[?@ >= 3 && @ != 6]", Expected: []interface{}{3, 5, 4}},
+		{Parameter: "$.a[?@.b == 'k']", Expected: []interface{}{map[string]interface{}{"b": "k"}}},
+		{Parameter: "$.a[?@ == null]", Expected: []interface{}{nil}},
+		{Parameter: "$.a[?@ == false]", Expected: []interface{}{false}},
+		{Parameter: "$.a[?@ == $.a[8]]", Expected: []interface{}{[]interface{}{}}},
+		{Parameter: "$.a[?@.b < 'k']", Expected: []interface{}{map[string]interface{}{"b": "j"}}},
+		{Parameter: "$.a[?@ == 1.0]", Expected: []interface{}{1}},
+		{Parameter: "$.a[?@ == 1e0]", Expected: []interface{}{1}},
+		{Parameter: "$.a[-2:]", Expected: []interface{}{nil, false}},
+		{Parameter: "$.a[100:-100:-3]", Expected: []interface{}{false, map[string]interface{}{"b": "k"}, 4, 5}},
+		{Parameter: "$.o['j b']", Expected: []interface{}{"x"}},
+		{Parameter: `$.o["it's"]`, Expected: []interface{}{"y"}},
+		{Parameter: "$.o[?@ == 'y']", Expected: []interface{}{"y"}},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			nodes, err := Query(data, test.Parameter)
+			if err != nil {
+				t.Fatalf("[%s] unexpected error %v", test.Parameter, err)
+			}
+
+			var actual []interface{}
+			if len(nodes) > 0 {
+				actual = nodes.Values()
+			}
+			if !reflect.DeepEqual(test.Expected, actual) {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestNormalizedPath(t *testing.T) {
+	data := nested.Map{"it's\n": map[string]interface{}{"\u0001": 1}}
+
+	nodes, err := Query(data, "$..*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{`$['it\'s\n']`, `$['it\'s\n']['\u0001']`}
+	if !reflect.DeepEqual(expected, nodes.Paths()) {
+		t.Errorf("expected %v, but got %v", expected, nodes.Paths())
+	}
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		Parameter string
+		Offset    int
+	}{
+		{Parameter: "store", Offset: 0},
+		{Parameter: " $", Offset: 0},
+		{Parameter: "$ ", Offset: 1},
+		{Parameter: "$.", Offset: 2},
+		{Parameter: "$.1a", Offset: 2},
+		{Parameter: "$[", Offset: 2},
+		{Parameter: "$[01]", Offset: 2},
+		{Parameter: "$[-0]", Offset: 2},
+		{Parameter: "$[9007199254740992]", Offset: 2},
+		{Parameter: "$[0 1]", Offset: 4},
+		{Parameter: "$['a]", Offset: 5},
+		{Parameter: `$['\a']`, Offset: 4},
+		{Parameter: `$['\ud800']`, Offset: 9},
+		{Parameter: "$[?@.a == 'b]", Offset: 13},
+		{Parameter: "$[?@.* == 1]", Offset: 3},
+		{Parameter: "$[?1 == @..a]", Offset: 8},
+		{Parameter: "$[?true]", Offset: 3},
+		{Parameter: "$[?foo(@)]", Offset: 3},
+		{Parameter: "$[?length(@.*) == 1]", Offset: 10},
+		{Parameter: "$[?count(1) == 1]", Offset: 9},
+		{Parameter: "$[?length(@) ]", Offset: 3},
+		{Parameter: "$[?match(@.a) ]", Offset: 12},
+		{Parameter: "$[?(@.a]", Offset: 7},
+		{Parameter: "$[?@.a == 01]", Offset: 10},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			_, err := Compile(test.Parameter)
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("[%s] expected *SyntaxError, but got %v", test.Parameter, err)
+			}
+			if syntaxErr.Offset != test.Offset {
+				t.Errorf("[%s] expected offset %d, but got %d (%v)", test.Parameter, test.Offset, syntaxErr.Offset, err)
+			}
+		})
+	}
+}
+
+func TestMustCompile(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+
+	MustCompile("$[")
+}
+
+func TestGet(t *testing.T) {
+	data, _ := nested.NewFromJSON(store)
+	nodes := MustCompile("$.store.bicycle.price").Query(data)
+
+	price, ok := Get[int](nodes[0])
+	if !ok || price != 399 {
+		t.Errorf("expected 399, but got %v", price)
+	}
+
+	if _, ok := Get[string](nodes[0]); ok {
+		t.Error("expected false for string")
+	}
+
+	var mismatch *nested.TypeMismatchError
+	if _, err := GetE[string](nodes[0]); !errors.As(err, &mismatch) || mismatch.Path != "$['store']['bicycle']['price']" {
+		t.Errorf("expected *nested.TypeMismatchError with the path of node, but got %v", err)
+	}
+
+	bicycle, ok := MustCompile("$.store.bicycle").Query(data)[0].Map()
+	if !ok || bicycle.GetString("color") != "red" {
+		t.Errorf("expected map with red color, but got %v", bicycle)
+	}
+}
+
+func ExampleQuery() {
+	data, _ := nested.NewFromJSON(store)
+
+	nodes, _ := Query(data, "$.store.book[?@.price < 10].title")
+	for _, n := range nodes {
+		fmt.Println(n.Path, n.Value)
+	}
+	// output:
+	// $['store']['book'][0]['title'] Sayings of the Century
+	// $['store']['book'][2]['title'] Moby Dick
+}
+
+func ExamplePath_Query() {
+	data, _ := nested.NewFromJSON(store)
+	path := MustCompile("$..book[-1]")
+
+	for _, n := range path.Query(data) {
+		book, _ := n.Map()
+		fmt.Println(book.GetString("author"), book.GetFloat64("price"))
+	}
+	// output:
+	// J. R. R. Tolkien 22.99
+}
+
+func BenchmarkQuery(b *testing.B) {
+	data, _ := nested.NewFromJSON(store)
+	path := MustCompile("$..book[?@.price < 10].title")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		path.Query(data)
+	}
+}
@@ -0,0 +1,682 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// maxInt is the biggest integer allowed in indexes and slices, 2^53-1 like RFC 9535.
+const maxInt = 1<<53 - 1
+
+// parser reads a JSONPath query from expr, pos is the offset of next character.
+type parser struct {
+	expr string
+	pos  int
+}
+
+// parseQuery parses the whole expr as a query that starts with $.
+func (p *parser) parseQuery() (*query, error) {
+	if !p.consume("$") {
+		return nil, p.errorf("query must start with $")
+	}
+
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.expr) {
+		return nil, p.errorf("unexpected character %q", p.expr[p.pos])
+	}
+
+	return &query{segments: segments}, nil
+}
+
+// parseSegments parses segments until there is no . (dot) or [ after the blank spaces.
+func (p *parser) parseSegments() ([]segment, error) {
+	var segments []segment
+	for {
+		start := p.pos
+		p.skipSpace()
+
+		if !p.peek('.') && !p.peek('[') {
+			p.pos = start
+			return segments, nil
+		}
+
+		seg, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, seg)
+	}
+}
+
+// parseSegment parses a child segment (.name, .* or [...]) or a descendant segment (..).
+func (p *parser) parseSegment() (segment, error) {
+	if p.consume("..") {
+		switch {
+		case p.peek('['):
+			selectors, err := p.parseBracket()
+			return segment{descendant: true, selectors: selectors}, err
+		case p.consume("*"):
+			return segment{descendant: true, selectors: []selector{wildcardSelector{}}}, nil
+		}
+
+		name, err := p.parseName()
+		return segment{descendant: true, selectors: []selector{nameSelector(name)}}, err
+	}
+
+	if p.consume(".") {
+		if p.consume("*") {
+			return segment{selectors: []selector{wildcardSelector{}}}, nil
+		}
+
+		name, err := p.parseName()
+		return segment{selectors: []selector{nameSelector(name)}}, err
+	}
+
+	selectors, err := p.parseBracket()
+	return segment{selectors: selectors}, err
+}
+
+// parseName parses the member name of shorthand notation like .name.
+func (p *parser) parseName() (string, error) {
+	start := p.pos
+	for p.pos < len(p.expr) {
+		r, size := utf8.DecodeRuneInString(p.expr[p.pos:])
+		if !isNameChar(r) || (p.pos == start && '0' <= r && r <= '9') {
+			break
+		}
+		p.pos += size
+	}
+
+	if p.pos == start {
+		return "", p.errorf("expected member name")
+	}
+	return p.expr[start:p.pos], nil
+}
+
+// isNameChar returns true if r can be used in a member name of shorthand notation.
+func isNameChar(r rune) bool {
+	return r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') ||
+		(r >= 0x80 && r != utf8.RuneError)
+}
+
+// parseBracket parses the selectors between [ and ] separated by , (comma).
+func (p *parser) parseBracket() ([]selector, error) {
+	if !p.consume("[") {
+		return nil, p.errorf("expected [")
+	}
+
+	var selectors []selector
+	for {
+		p.skipSpace()
+
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+
+		p.skipSpace()
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or ]")
+		}
+	}
+}
+
+// parseSelector parses a name, wildcard, index, slice or filter selector.
+func (p *parser) parseSelector() (selector, error) {
+	switch {
+	case p.peek('\'') || p.peek('"'):
+		name, err := p.parseString()
+		return nameSelector(name), err
+	case p.consume("*"):
+		return wildcardSelector{}, nil
+	case p.consume("?"):
+		p.skipSpace()
+		expr, err := p.parseOr()
+		return filterSelector{expr: expr}, err
+	}
+
+	var start, end, step *int
+	if !p.peek(':') {
+		i, err := p.parseInt()
+		if err != nil {
+			return nil, err
+		}
+
+		start = &i
+		p.skipSpace()
+		if !p.peek(':') {
+			return indexSelector(i), nil
+		}
+	}
+
+	p.consume(":")
+	p.skipSpace()
+	if p.peek('-') || p.peekDigit() {
+		i, err := p.parseInt()
+		if err != nil {
+			return nil, err
+		}
+		end = &i
+		p.skipSpace()
+	}
+
+	if p.consume(":") {
+		p.skipSpace()
+		if p.peek('-') || p.peekDigit() {
+			i, err := p.parseInt()
+			if err != nil {
+				return nil, err
+			}
+			step = &i
+		}
+	}
+
+	return sliceSelector{start: start, end: end, step: step}, nil
+}
+
+// parseInt parses an integer without leading zeros between -(2^53-1) and 2^53-1.
+func (p *parser) parseInt() (int, error) {
+	start := p.pos
+	p.consume("-")
+
+	digits := p.pos
+	for p.peekDigit() {
+		p.pos++
+	}
+
+	text := p.expr[start:p.pos]
+	switch {
+	case p.pos == digits:
+		p.pos = start
+		return 0, p.errorf("expected integer")
+	case p.expr[digits] == '0' && (p.pos-digits > 1 || digits > start):
+		p.pos = start
+		return 0, p.errorf("invalid integer %q", text)
+	}
+
+	i, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || i > maxInt || i < -maxInt {
+		p.pos = start
+		return 0, p.errorf("integer %q out of range", text)
+	}
+	return int(i), nil
+}
+
+// parseString parses a string between single or double quotes with the escapes of JSON,
+// \' is allowed only in single quotes and \" only in double quotes.
+func (p *parser) parseString() (string, error) {
+	quote := p.expr[p.pos]
+	p.pos++
+
+	var b strings.Builder
+	for p.pos < len(p.expr) {
+		c := p.expr[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return b.String(), nil
+		case c < 0x20:
+			return "", p.errorf("invalid character %q in string", c)
+		case c != '\\':
+			b.WriteByte(c)
+			p.pos++
+			continue
+		}
+
+		p.pos++
+		if p.pos >= len(p.expr) {
+			break
+		}
+
+		switch e := p.expr[p.pos]; e {
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '/', '\\':
+			b.WriteByte(e)
+		case 'u':
+			r, err := p.parseUnicode()
+			if err != nil {
+				return "", err
+			}
+			b.WriteRune(r)
+			continue
+		default:
+			if e != quote {
+				return "", p.errorf("invalid escape \\%c", e)
+			}
+			b.WriteByte(e)
+		}
+		p.pos++
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+// parseUnicode parses the escape \uXXXX after \ with the surrogate pair if there is one.
+func (p *parser) parseUnicode() (rune, error) {
+	r, err := p.parseHex()
+	if err != nil {
+		return 0, err
+	}
+
+	if !utf16.IsSurrogate(r) {
+		return r, nil
+	}
+
+	if r >= 0xDC00 || !p.peekString(`\u`) {
+		return 0, p.errorf("invalid surrogate pair")
+	}
+
+	p.pos++
+	low, err := p.parseHex()
+	if err != nil {
+		return 0, err
+	}
+
+	r = utf16.DecodeRune(r, low)
+	if r == utf8.RuneError {
+		return 0, p.errorf("invalid surrogate pair")
+	}
+	return r, nil
+}
+
+// parseHex parses the u and four hexadecimal digits of an escape.
+func (p *parser) parseHex() (rune, error) {
+	if p.pos+5 > len(p.expr) {
+		return 0, p.errorf("invalid unicode escape")
+	}
+
+	r, err := strconv.ParseUint(p.expr[p.pos+1:p.pos+5], 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid unicode escape")
+	}
+
+	p.pos += 5
+	return rune(r), nil
+}
+
+// parseOr parses logical expressions separated by ||.
+func (p *parser) parseOr() (logical, error) {
+	var exprs orExpr
+	for {
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+
+		if !p.consumeOperator("||") {
+			break
+		}
+	}
+
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return exprs, nil
+}
+
+// parseAnd parses logical expressions separated by &&.
+func (p *parser) parseAnd() (logical, error) {
+	var exprs andExpr
+	for {
+		expr, err := p.parseBasic()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+
+		if !p.consumeOperator("&&") {
+			break
+		}
+	}
+
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return exprs, nil
+}
+
+// consumeOperator consumes op after the blank spaces and the blank spaces after it.
+func (p *parser) consumeOperator(op string) bool {
+	start := p.pos
+	p.skipSpace()
+
+	if !p.consume(op) {
+		p.pos = start
+		return false
+	}
+
+	p.skipSpace()
+	return true
+}
+
+// parseBasic parses an expression between parentheses, a comparison or a test of a query or function.
+func (p *parser) parseBasic() (logical, error) {
+	if p.consume("!") {
+		p.skipSpace()
+
+		if p.peek('(') {
+			expr, err := p.parseParen()
+			return notExpr{expr: expr}, err
+		}
+
+		start := p.pos
+		expr, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		test, err := p.testExpr(expr, start)
+		return notExpr{expr: test}, err
+	}
+
+	if p.peek('(') {
+		return p.parseParen()
+	}
+
+	start := p.pos
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	op, ok := p.parseComparison()
+	if !ok {
+		return p.testExpr(left, start)
+	}
+
+	if err := p.checkComparable(left, start); err != nil {
+		return nil, err
+	}
+
+	start = p.pos
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.checkComparable(right, start); err != nil {
+		return nil, err
+	}
+
+	return comparisonExpr{left: operandOf(left), right: operandOf(right), op: op}, nil
+}
+
+// parseParen parses a logical expression between parentheses.
+func (p *parser) parseParen() (logical, error) {
+	p.consume("(")
+	p.skipSpace()
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.consume(")") {
+		return nil, p.errorf("expected )")
+	}
+	return expr, nil
+}
+
+// parseComparison parses the comparison operator after the blank spaces.
+func (p *parser) parseComparison() (string, bool) {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consumeOperator(op) {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// testExpr returns the test of a query or of a function with logical result,
+// start is the offset of expr used in errors.
+func (p *parser) testExpr(expr interface{}, start int) (logical, error) {
+	switch e := expr.(type) {
+	case *query:
+		return existsExpr{query: e}, nil
+	case functionExpr:
+		if e.fn.result == logicalKind {
+			return e, nil
+		}
+	}
+
+	p.pos = start
+	return nil, p.errorf("expression must be a query or a logical function, or it needs a comparison")
+}
+
+// checkComparable returns error if expr cannot be used in comparisons,
+// only literals, singular queries and functions with value result can be compared.
+func (p *parser) checkComparable(expr interface{}, start int) error {
+	switch e := expr.(type) {
+	case literal:
+		return nil
+	case *query:
+		if e.singular() {
+			return nil
+		}
+	case functionExpr:
+		if e.fn.result == valueKind {
+			return nil
+		}
+	}
+
+	p.pos = start
+	return p.errorf("expression cannot be compared, it must be a literal, a singular query or a function with value result")
+}
+
+// operandOf returns the operand for a literal, a singular query or a function.
+func operandOf(expr interface{}) operand {
+	if q, ok := expr.(*query); ok {
+		return singularQuery{query: q}
+	}
+	return expr.(operand)
+}
+
+// parseOperand parses a literal, a query that starts with @ or $ or a function call,
+// the result is literal, *query or functionExpr.
+func (p *parser) parseOperand() (interface{}, error) {
+	switch {
+	case p.peek('\'') || p.peek('"'):
+		s, err := p.parseString()
+		return literal{v: s}, err
+	case p.peek('-') || p.peekDigit():
+		return p.parseNumber()
+	case p.peek('@') || p.peek('$'):
+		relative := p.peek('@')
+		p.pos++
+
+		segments, err := p.parseSegments()
+		return &query{relative: relative, segments: segments}, err
+	}
+
+	start := p.pos
+	for p.pos < len(p.expr) {
+		c := p.expr[p.pos]
+		if !('a' <= c && c <= 'z') && !(p.pos > start && (c == '_' || ('0' <= c && c <= '9'))) {
+			break
+		}
+		p.pos++
+	}
+
+	name := p.expr[start:p.pos]
+	if p.peek('(') {
+		return p.parseFunction(name, start)
+	}
+
+	switch name {
+	case "true":
+		return literal{v: true}, nil
+	case "false":
+		return literal{v: false}, nil
+	case "null":
+		return literal{v: nil}, nil
+	}
+
+	p.pos = start
+	return nil, p.errorf("expected literal, query or function")
+}
+
+// parseNumber parses a number literal of JSON, -0 is allowed. It is kept as json.Number.
+func (p *parser) parseNumber() (interface{}, error) {
+	start := p.pos
+	p.consume("-")
+
+	digits := p.pos
+	for p.peekDigit() {
+		p.pos++
+	}
+	if p.pos == digits || (p.expr[digits] == '0' && p.pos-digits > 1) {
+		p.pos = start
+		return nil, p.errorf("invalid number")
+	}
+
+	if p.consume(".") {
+		if !p.peekDigit() {
+			return nil, p.errorf("invalid number")
+		}
+		for p.peekDigit() {
+			p.pos++
+		}
+	}
+
+	if p.peek('e') || p.peek('E') {
+		p.pos++
+		if !p.consume("-") {
+			p.consume("+")
+		}
+		if !p.peekDigit() {
+			return nil, p.errorf("invalid number")
+		}
+		for p.peekDigit() {
+			p.pos++
+		}
+	}
+
+	return literal{v: json.Number(p.expr[start:p.pos])}, nil
+}
+
+// parseFunction parses the arguments of function name and checks their types.
+func (p *parser) parseFunction(name string, start int) (interface{}, error) {
+	fn, ok := functions[name]
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("unknown function %q", name)
+	}
+
+	p.consume("(")
+	p.skipSpace()
+
+	var args []interface{}
+	for !p.peek(')') {
+		if len(args) > 0 {
+			if !p.consume(",") {
+				return nil, p.errorf("expected , or )")
+			}
+			p.skipSpace()
+		}
+
+		argStart := p.pos
+		arg, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(args) >= len(fn.params) {
+			p.pos = argStart
+			return nil, p.errorf("too many arguments for function %s", name)
+		}
+
+		arg, err = p.argument(arg, fn.params[len(args)], argStart)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		p.skipSpace()
+	}
+	if len(args) != len(fn.params) {
+		return nil, p.errorf("function %s expects %d arguments", name, len(fn.params))
+	}
+	p.consume(")")
+
+	return functionExpr{fn: fn, args: args}, nil
+}
+
+// argument checks that arg has the type of param and returns it as operand or *query.
+func (p *parser) argument(arg interface{}, param kind, start int) (interface{}, error) {
+	if param == nodesKind {
+		if q, ok := arg.(*query); ok {
+			return q, nil
+		}
+
+		p.pos = start
+		return nil, p.errorf("argument must be a query")
+	}
+
+	if err := p.checkComparable(arg, start); err != nil {
+		return nil, err
+	}
+	return operandOf(arg), nil
+}
+
+// skipSpace skips the blank spaces: space, tab, line feed and carriage return.
+func (p *parser) skipSpace() {
+	for p.pos < len(p.expr) {
+		switch p.expr[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// peek returns true if the next character is c.
+func (p *parser) peek(c byte) bool {
+	return p.pos < len(p.expr) && p.expr[p.pos] == c
+}
+
+// peekString returns true if expr continues with s.
+func (p *parser) peekString(s string) bool {
+	return strings.HasPrefix(p.expr[p.pos:], s)
+}
+
+// peekDigit returns true if the next character is a digit.
+func (p *parser) peekDigit() bool {
+	return p.pos < len(p.expr) && '0' <= p.expr[p.pos] && p.expr[p.pos] <= '9'
+}
+
+// consume moves after s if expr continues with s.
+func (p *parser) consume(s string) bool {
+	if !p.peekString(s) {
+		return false
+	}
+	p.pos += len(s)
+	return true
+}
+
+// errorf returns *SyntaxError at the current offset.
+func (p *parser) errorf(msg string, args ...interface{}) error {
+	return &SyntaxError{Expr: p.expr, Offset: p.pos, Msg: fmt.Sprintf(msg, args...)}
+}
@@ -0,0 +1,217 @@
+package nested
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// InterfaceE returns the value from position that you pass separately by . (dot) like Interface,
+// it returns *PathNotFoundError with the segment that is missing if the field is not found
+// or *SyntaxError if the position is not valid.
+func (m Map) InterfaceE(position string, opts ...Option) (interface{}, error) {
+	pos, err := parsePosition(position)
+	if err != nil {
+		return nil, err
+	}
+
+	return find(m, position, pos, opts)
+}
+
+// find returns the value of segments pos from m, position is used in the errors.
+func find(m Map, position string, pos []segment, opts []Option) (interface{}, error) {
+	value, missing := walk(map[string]interface{}(m), pos, newOptions(opts).loose)
+	if missing >= 0 {
+		missingKey := ""
+		if missing < len(pos) {
+			missingKey = pos[missing].key
+		}
+		return nil, &PathNotFoundError{Path: position, MissingSegment: missingKey}
+	}
+
+	return value, nil
+}
+
+// StringE returns the string value from position that you passed by argument,
+// it returns *TypeMismatchError if the value is not a string.
+func (m Map) StringE(position string) (string, error) {
+	return GetE[string](m, position)
+}
+
+// IntE returns the int value from position that you passed by argument converted like Int,
+// it returns *TypeMismatchError if the value cannot be converted to int.
+func (m Map) IntE(position string, opts ...Option) (int, error) {
+	return GetE[int](m, position, opts...)
+}
+
+// Int64E returns the int64 value from position that you passed by argument converted like Int64,
+// it returns *TypeMismatchError if the value cannot be converted to int64.
+func (m Map) Int64E(position string, opts ...Option) (int64, error) {
+	return GetE[int64](m, position, opts...)
+}
+
+// UintE returns the uint value from position that you passed by argument converted like Uint,
+// it returns *TypeMismatchError if the value cannot be converted to uint.
+func (m Map) UintE(position string, opts ...Option) (uint, error) {
+	return GetE[uint](m, position, opts...)
+}
+
+// Uint64E returns the uint64 value from position that you passed by argument converted like Uint64,
+// it returns *TypeMismatchError if the value cannot be converted to uint64.
+func (m Map) Uint64E(position string, opts ...Option) (uint64, error) {
+	return GetE[uint64](m, position, opts...)
+}
+
+// Float64E returns the float64 value from position that you passed by argument converted like Float64,
+// it returns *TypeMismatchError if the value cannot be converted to float64.
+func (m Map) Float64E(position string, opts ...Option) (float64, error) {
+	return GetE[float64](m, position, opts...)
+}
+
+// BoolE returns the bool value from position that you passed by argument,
+// it returns *TypeMismatchError if the value is not a bool.
+func (m Map) BoolE(position string) (bool, error) {
+	return GetE[bool](m, position)
+}
+
+// TimeE returns the time.Time value from position that you passed by argument like Time,
+// it returns *ParseError if the value cannot be parsed with the layout.
+func (m Map) TimeE(position, layout string, opts ...Option) (time.Time, error) {
+	return GetE[time.Time](m, position, append([]Option{Layout(layout)}, opts...)...)
+}
+
+// SubFromStringE returns Map from string json format in position that you passed by argument,
+// it returns *ParseError if the value is not a valid json.
+func (m Map) SubFromStringE(position string) (Map, error) {
+	subData, err := m.StringE(position)
+	if err != nil {
+		return nil, err
+	}
+
+	var value Map
+	if err := json.Unmarshal([]byte(subData), &value); err != nil {
+		return nil, &ParseError{Path: position, Layout: "json", Err: err}
+	}
+	return value, nil
+}
+
+// SliceE returns the slice value from position that you passed by argument like Slice,
+// it returns *TypeMismatchError if the value is not a slice.
+func (m Map) SliceE(position string) ([]interface{}, error) {
+	return GetE[[]interface{}](m, position)
+}
+
+// StringSliceE returns the []string value from position that you passed by argument,
+// it returns *TypeMismatchError with the position of element if some element is not a string.
+func (m Map) StringSliceE(position string) ([]string, error) {
+	return GetE[[]string](m, position)
+}
+
+// IntSliceE returns the []int value from position that you passed by argument,
+// it returns *TypeMismatchError with the position of element if some element cannot be converted to int.
+func (m Map) IntSliceE(position string, opts ...Option) ([]int, error) {
+	return GetE[[]int](m, position, opts...)
+}
+
+// MapSliceE returns the []Map value from position that you passed by argument,
+// it returns *TypeMismatchError with the position of element if some element is not a map.
+func (m Map) MapSliceE(position string) ([]Map, error) {
+	return GetE[[]Map](m, position)
+}
+
+// toSlice returns value as []interface{}, typed slices are copied element by element.
+func toSlice(valueTmp interface{}) ([]interface{}, bool) {
+	if value, ok := valueTmp.([]interface{}); ok {
+		return value, true
+	}
+
+	rv := reflect.ValueOf(valueTmp)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	value := make([]interface{}, rv.Len())
+	for i := range value {
+		value[i] = rv.Index(i).Interface()
+	}
+	return value, true
+}
+
+// elementPosition returns the position of element index inside of the slice in position.
+func elementPosition(position string, index int) string {
+	if isPointer(position) {
+		return position + "/" + strconv.Itoa(index)
+	}
+	return position + "." + strconv.Itoa(index)
+}
+
+// InterfaceE is helper for function InterfaceE from Map.
+func InterfaceE(position string, mapper map[string]interface{}, opts ...Option) (interface{}, error) {
+	return New(mapper).InterfaceE(position, opts...)
+}
+
+// StringE is helper for function StringE from Map.
+func StringE(position string, mapper map[string]interface{}) (string, error) {
+	return New(mapper).StringE(position)
+}
+
+// IntE is helper for function IntE from Map.
+func IntE(position string, mapper map[string]interface{}, opts ...Option) (int, error) {
+	return New(mapper).IntE(position, opts...)
+}
+
+// Int64E is helper for function Int64E from Map.
+func Int64E(position string, mapper map[string]interface{}, opts ...Option) (int64, error) {
+	return New(mapper).Int64E(position, opts...)
+}
+
+// UintE is helper for function UintE from Map.
+func UintE(position string, mapper map[string]interface{}, opts ...Option) (uint, error) {
+	return New(mapper).UintE(position, opts...)
+}
+
+// Uint64E is helper for function Uint64E from Map.
+func Uint64E(position string, mapper map[string]interface{}, opts ...Option) (uint64, error) {
+	return New(mapper).Uint64E(position, opts...)
+}
+
+// Float64E is helper for function Float64E from Map.
+func Float64E(position string, mapper map[string]interface{}, opts ...Option) (float64, error) {
+	return New(mapper).Float64E(position, opts...)
+}
+
+// BoolE is helper for function BoolE from Map.
+func BoolE(position string, mapper map[string]interface{}) (bool, error) {
+	return New(mapper).BoolE(position)
+}
+
+// TimeE is helper for function TimeE from Map.
+func TimeE(position string, mapper map[string]interface{}, layout string, opts ...Option) (time.Time, error) {
+	return New(mapper).TimeE(position, layout, opts...)
+}
+
+// SubFromStringE is helper for function SubFromStringE from Map.
+func SubFromStringE(position string, mapper map[string]interface{}) (Map, error) {
+	return New(mapper).SubFromStringE(position)
+}
+
+// SliceE is helper for function SliceE from Map.
+func SliceE(position string, mapper map[string]interface{}) ([]interface{}, error) {
+	return New(mapper).SliceE(position)
+}
+
+// StringSliceE is helper for function StringSliceE from Map.
+func StringSliceE(position string, mapper map[string]interface{}) ([]string, error) {
+	return New(mapper).StringSliceE(position)
+}
+
+// IntSliceE is helper for function IntSliceE from Map.
+func IntSliceE(position string, mapper map[string]interface{}, opts ...Option) ([]int, error) {
+	return New(mapper).IntSliceE(position, opts...)
+}
+
+// MapSliceE is helper for function MapSliceE from Map.
+func MapSliceE(position string, mapper map[string]interface{}) ([]Map, error) {
+	return New(mapper).MapSliceE(position)
+}
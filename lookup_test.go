@@ -0,0 +1,203 @@
+package nested
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInterfaceE(t *testing.T) {
+	tests := []struct {
+		Parameter string
+		Expected  interface{}
+		Error     error
+	}{
+		{
+			Parameter: "advert.status.code",
+			Expected:  "active",
+		},
+		{
+			Parameter: "advert.status.bananas",
+			Error:     &PathNotFoundError{Path: "advert.status.bananas", MissingSegment: "bananas"},
+		},
+		{
+			Parameter: "advert.owner.id",
+			Error:     &PathNotFoundError{Path: "advert.owner.id", MissingSegment: "owner"},
+		},
+		{
+			Parameter: "advert.contact.phones[5]",
+			Error:     &PathNotFoundError{Path: "advert.contact.phones[5]", MissingSegment: "5"},
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, err := InterfaceE(test.Parameter, data)
+			if !reflect.DeepEqual(test.Expected, actual) || !reflect.DeepEqual(test.Error, err) {
+				t.Errorf("[%s] expected %v and error %v, but got %v and error %v",
+					test.Parameter, test.Expected, test.Error, actual, err)
+			}
+		})
+	}
+
+	t.Run("TestInterfaceEInvalidPosition", func(t *testing.T) {
+		if _, err := InterfaceE("advert[0", data); !errors.Is(err, ErrInvalidPosition) {
+			t.Errorf("Expected error %v, but got %v", ErrInvalidPosition, err)
+		}
+	})
+}
+
+func TestTypedE(t *testing.T) {
+	mapper := map[string]interface{}{
+		"person": map[string]interface{}{
+			"name":   "Rodrigo",
+			"level":  3.5,
+			"age":    "33",
+			"active": "yes",
+			"tags":   []interface{}{"new", 1},
+			"rates":  []interface{}{5, "4"},
+		},
+	}
+
+	tests := []struct {
+		Name   string
+		Lookup func() error
+		Error  error
+	}{
+		{
+			Name:   "StringE",
+			Lookup: func() error { _, err := StringE("person.level", mapper); return err },
+			Error:  &TypeMismatchError{Path: "person.level", Want: "string", Got: "float64"},
+		},
+		{
+			Name:   "IntE",
+			Lookup: func() error { _, err := IntE("person.age", mapper); return err },
+			Error:  &TypeMismatchError{Path: "person.age", Want: "int", Got: "string"},
+		},
+		{
+			Name:   "Uint64E",
+			Lookup: func() error { _, err := Uint64E("person.name", mapper); return err },
+			Error:  &TypeMismatchError{Path: "person.name", Want: "uint64", Got: "string"},
+		},
+		{
+			Name:   "BoolE",
+			Lookup: func() error { _, err := BoolE("person.active", mapper); return err },
+			Error:  &TypeMismatchError{Path: "person.active", Want: "bool", Got: "string"},
+		},
+		{
+			Name:   "SliceE",
+			Lookup: func() error { _, err := SliceE("person.name", mapper); return err },
+			Error:  &TypeMismatchError{Path: "person.name", Want: "[]interface {}", Got: "string"},
+		},
+		{
+			Name:   "StringSliceE",
+			Lookup: func() error { _, err := StringSliceE("person.tags", mapper); return err },
+			Error:  &TypeMismatchError{Path: "person.tags.1", Want: "string", Got: "int"},
+		},
+		{
+			Name:   "IntSliceE",
+			Lookup: func() error { _, err := IntSliceE("person.rates", mapper); return err },
+			Error:  &TypeMismatchError{Path: "person.rates.1", Want: "int", Got: "string"},
+		},
+		{
+			Name:   "MapSliceE",
+			Lookup: func() error { _, err := MapSliceE("person.tags", mapper); return err },
+			Error:  &TypeMismatchError{Path: "person.tags.0", Want: "nested.Map", Got: "string"},
+		},
+		{
+			Name:   "Float64E",
+			Lookup: func() error { _, err := Float64E("person.bananas", mapper); return err },
+			Error:  &PathNotFoundError{Path: "person.bananas", MissingSegment: "bananas"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if err := test.Lookup(); !reflect.DeepEqual(test.Error, err) {
+				t.Errorf("[%s] expected error %v, but got %v", test.Name, test.Error, err)
+			}
+		})
+	}
+
+	t.Run("TestIntEFractional", func(t *testing.T) {
+		_, err := IntE("person.level", mapper)
+
+		var mismatch *TypeMismatchError
+		if !errors.As(err, &mismatch) || !errors.Is(err, ErrFractional) {
+			t.Errorf("Expected a *TypeMismatchError with %v, but got %v", ErrFractional, err)
+		}
+	})
+
+	t.Run("TestInt64ELenient", func(t *testing.T) {
+		value, err := Int64E("person.age", mapper, Lenient())
+		if value != 33 || err != nil {
+			t.Errorf("Expected 33 and error nil, but got %v and error %v", value, err)
+		}
+	})
+}
+
+func TestTimeE(t *testing.T) {
+	_, err := TimeE("advert.timer.birth", data, time.RFC3339)
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected a *ParseError, but got %v", err)
+	}
+	if parseErr.Path != "advert.timer.birth" || parseErr.Layout != time.RFC3339 {
+		t.Errorf("Expected path advert.timer.birth and layout %s, but got %s and %s", time.RFC3339, parseErr.Path, parseErr.Layout)
+	}
+
+	var timeErr *time.ParseError
+	if !errors.As(err, &timeErr) {
+		t.Errorf("Expected a *time.ParseError wrapped, but got %v", err)
+	}
+
+	value, err := TimeE("advert.timer.birth", data, "02/01/2006")
+	if value.UnixNano() != 538876800000000000 || err != nil {
+		t.Errorf("Expected 538876800000000000 and error nil, but got %v and error %v", value.UnixNano(), err)
+	}
+}
+
+func TestSubFromStringE(t *testing.T) {
+	_, err := SubFromStringE("advert.extras_error", data)
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Layout != "json" {
+		t.Errorf("Expected a *ParseError with layout json, but got %v", err)
+	}
+
+	if _, err := SubFromStringE("advert.extras", data); err != nil {
+		t.Errorf("Expected error nil, but got %v", err)
+	}
+}
+
+func TestNewFromJSONError(t *testing.T) {
+	_, err := NewFromJSON(`{"first_name": `)
+	if !errors.Is(err, ErrInvalidInputType) {
+		t.Errorf("Expected error %v, but got %v", ErrInvalidInputType, err)
+	}
+
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("Expected a *json.SyntaxError wrapped, but got %v", err)
+	}
+}
+func ExampleMap_IntE() {
+	data := New(map[string]interface{}{
+		"person": map[string]interface{}{
+			"level": "3",
+		},
+	})
+
+	_, err := data.IntE("person.level")
+	fmt.Println(err)
+
+	_, err = data.IntE("person.age")
+	fmt.Println(err)
+	// output:
+	// the position "person.level" is string, not int
+	// the position "person.age" is not found, missing segment "age"
+}
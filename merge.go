@@ -0,0 +1,337 @@
+package nested
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// ConflictStrategy is what Merge does when both maps have different values in the same position.
+type ConflictStrategy int
+
+const (
+	// ConflictOverride replaces the value of Map by the value of other Map, it is the default.
+	ConflictOverride ConflictStrategy = iota
+	// ConflictKeep keeps the value of Map.
+	ConflictKeep
+	// ConflictFail stops the merge and returns an error.
+	ConflictFail
+)
+
+// SliceStrategy is how Merge joins the slices that are in the same position of both maps.
+type SliceStrategy int
+
+const (
+	// SliceReplace uses the slice like any other value, it is replaced following the ConflictStrategy, it is the default.
+	SliceReplace SliceStrategy = iota
+	// SliceAppend appends the elements of other Map after the elements of Map.
+	SliceAppend
+	// SliceMergeByIndex merges the elements with the same index and appends the extra elements of other Map.
+	SliceMergeByIndex
+	// SliceMergeByKey merges the maps that have the same value in the key field defined by MergeByKey
+	// and appends the other elements of other Map.
+	SliceMergeByKey
+)
+
+// OnConflict changes what Merge does when both maps have different values in the same position.
+func OnConflict(strategy ConflictStrategy) Option {
+	return func(o *options) {
+		o.conflict = strategy
+	}
+}
+
+// OnTypeMismatch changes what Merge does when both maps have values of different types in the same position,
+// like a map and a string. By default the value is replaced, ConflictFail returns *TypeMismatchError.
+func OnTypeMismatch(strategy ConflictStrategy) Option {
+	return func(o *options) {
+		o.mismatch = strategy
+	}
+}
+
+// MergeSlices changes how Merge joins the slices that are in the same position of both maps.
+func MergeSlices(strategy SliceStrategy) Option {
+	return func(o *options) {
+		o.slices = strategy
+	}
+}
+
+// MergeByKey makes Merge join the slices of maps by the value of field, like the id of items.
+func MergeByKey(field string) Option {
+	return func(o *options) {
+		o.slices = SliceMergeByKey
+		o.keyField = field
+	}
+}
+
+// MergeReport has the positions changed by Merge.
+type MergeReport struct {
+	// Overwritten has the positions where the value of Map was replaced by the value of other Map.
+	Overwritten []string
+	// Kept has the positions where the value of Map was kept because of ConflictKeep.
+	Kept []string
+}
+
+// Merge copies the values of other to Map going through the nested maps, the values that exist only in other are added.
+// When both maps have a different value in the same position it follows the strategies of OnConflict, OnTypeMismatch
+// and MergeSlices, by default the value of other is used. It returns *ConflictError or *TypeMismatchError for ConflictFail,
+// in this case the Map is not changed, and ErrInvalidInputType if the Map is nil.
+func (m Map) Merge(other Map, opts ...Option) (MergeReport, error) {
+	if m == nil {
+		return MergeReport{}, fmt.Errorf("%w: Merge needs a non-nil Map", ErrInvalidInputType)
+	}
+
+	mg := merger{o: newOptions(opts)}
+
+	out := deepCopy(map[string]interface{}(m)).(map[string]interface{})
+	if err := mg.mergeMap(out, "", map[string]interface{}(other)); err != nil {
+		return MergeReport{}, err
+	}
+
+	for key := range m {
+		delete(m, key)
+	}
+	for key, value := range out {
+		m[key] = value
+	}
+	return mg.report, nil
+}
+
+// merger merges the values following the strategies of options.
+type merger struct {
+	o      options
+	report MergeReport
+}
+
+// mergeMap merges all values of src into dst, position is the position of dst.
+func (mg *merger) mergeMap(dst map[string]interface{}, position string, src interface{}) error {
+	for _, item := range children(src) {
+		existing, ok := dst[item.Path]
+		if !ok {
+			dst[item.Path] = deepCopy(item.Value)
+			continue
+		}
+
+		value, err := mg.mergeValue(keyPosition(position, item.Path), existing, item.Value)
+		if err != nil {
+			return err
+		}
+		dst[item.Path] = value
+	}
+	return nil
+}
+
+// mergeValue returns the result of merge of incoming into existing in position.
+func (mg *merger) mergeValue(position string, existing, incoming interface{}) (interface{}, error) {
+	existingKind, incomingKind := valueKind(existing), valueKind(incoming)
+	if existing != nil && incoming != nil && existingKind != incomingKind {
+		err := mismatch(position, fmt.Sprintf("%T", existing), incoming, nil)
+		return mg.resolve(position, existing, incoming, mg.o.mismatch, err)
+	}
+
+	switch {
+	case existingKind == "map" && incoming != nil:
+		dst, isMap := existing.(map[string]interface{})
+		if !isMap {
+			dst = make(map[string]interface{})
+			for _, item := range children(existing) {
+				dst[item.Path] = item.Value
+			}
+		}
+
+		if err := mg.mergeMap(dst, position, incoming); err != nil {
+			return nil, err
+		}
+
+		if _, ok := existing.(Map); ok {
+			return Map(dst), nil
+		}
+		return dst, nil
+	case existingKind == "slice" && incoming != nil && mg.o.slices != SliceReplace:
+		return mg.mergeSlice(position, existing, incoming)
+	}
+
+	if reflect.DeepEqual(existing, incoming) {
+		return existing, nil
+	}
+
+	err := &ConflictError{Path: position, Existing: existing, Incoming: incoming}
+	return mg.resolve(position, existing, incoming, mg.o.conflict, err)
+}
+
+// mergeSlice joins the slices existing and incoming following the SliceStrategy.
+func (mg *merger) mergeSlice(position string, existing, incoming interface{}) (interface{}, error) {
+	dst, _ := toSlice(existing)
+	src, _ := toSlice(incoming)
+	out := append([]interface{}{}, dst...)
+
+	switch mg.o.slices {
+	case SliceMergeByIndex:
+		for i, item := range src {
+			if i >= len(out) {
+				out = append(out, deepCopy(item))
+				continue
+			}
+
+			value, err := mg.mergeValue(elementPosition(position, i), out[i], item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = value
+		}
+		return out, nil
+	case SliceMergeByKey:
+		indexes := make(map[interface{}]int)
+		for i, item := range out {
+			if key, ok := mg.itemKey(item); ok {
+				indexes[key] = i
+			}
+		}
+
+		for _, item := range src {
+			key, ok := mg.itemKey(item)
+			if i, found := indexes[key]; ok && found {
+				value, err := mg.mergeValue(elementPosition(position, i), out[i], item)
+				if err != nil {
+					return nil, err
+				}
+				out[i] = value
+				continue
+			}
+
+			if ok {
+				indexes[key] = len(out)
+			}
+			out = append(out, deepCopy(item))
+		}
+		return out, nil
+	}
+
+	for _, item := range src {
+		out = append(out, deepCopy(item))
+	}
+	return out, nil
+}
+
+// numberKey is the key of numbers in SliceMergeByKey, so int 1 and float64 1 are the same key but not "1".
+type numberKey string
+
+// itemKey returns the value of key field of item used by SliceMergeByKey.
+func (mg *merger) itemKey(item interface{}) (interface{}, bool) {
+	if valueKind(item) != "map" || mg.o.keyField == "" {
+		return nil, false
+	}
+
+	key, ok := child(item, segment{key: mg.o.keyField})
+	if !ok || key == nil || isContainer(key) {
+		return nil, false
+	}
+
+	if valueKind(key) == "number" {
+		return numberKey(fmt.Sprint(key)), true
+	}
+	return key, true
+}
+
+// resolve returns the value for a conflict in position following strategy, err is returned for ConflictFail.
+func (mg *merger) resolve(position string, existing, incoming interface{}, strategy ConflictStrategy, err error) (interface{}, error) {
+	switch strategy {
+	case ConflictKeep:
+		mg.report.Kept = append(mg.report.Kept, position)
+		return existing, nil
+	case ConflictFail:
+		return nil, err
+	}
+
+	mg.report.Overwritten = append(mg.report.Overwritten, position)
+	return deepCopy(incoming), nil
+}
+
+// valueKind returns the kind of value like JSON: map, slice, string, number, bool or null,
+// other types return their name.
+func valueKind(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+	if _, ok := value.(json.Number); ok {
+		return "number"
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		return "map"
+	case reflect.Slice, reflect.Array:
+		return "slice"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// deepCopy returns a copy of value where the maps and slices are copied too, so changes
+// in the copy don't change value. The types of maps and slices are kept.
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = deepCopy(item)
+		}
+		return out
+	case Map:
+		return Map(deepCopy(map[string]interface{}(v)).(map[string]interface{}))
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = deepCopy(item)
+		}
+		return out
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.IsNil() {
+			return value
+		}
+
+		out := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), copyValue(iter.Value(), rv.Type().Elem()))
+		}
+		return out.Interface()
+	case reflect.Slice:
+		if rv.IsNil() {
+			return value
+		}
+
+		out := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			out.Index(i).Set(copyValue(rv.Index(i), rv.Type().Elem()))
+		}
+		return out.Interface()
+	}
+
+	return value
+}
+
+// copyValue returns a deep copy of rv as a value of type typ.
+func copyValue(rv reflect.Value, typ reflect.Type) reflect.Value {
+	out := deepCopy(rv.Interface())
+	if out == nil {
+		return reflect.Zero(typ)
+	}
+	return reflect.ValueOf(out)
+}
+
+// Merge is helper for function Merge from Map.
+func Merge(mapper, other map[string]interface{}, opts ...Option) (MergeReport, error) {
+	return New(mapper).Merge(other, opts...)
+}
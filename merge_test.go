@@ -0,0 +1,194 @@
+package nested
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func defaultsConfig() Map {
+	return Map{
+		"server": map[string]interface{}{
+			"host":  "localhost",
+			"port":  8080,
+			"tls":   false,
+			"proxy": nil,
+		},
+		"hosts":  []interface{}{"a", "b"},
+		"labels": map[string]string{"env": "dev"},
+		"plugins": []interface{}{
+			map[string]interface{}{"name": "auth", "enabled": true},
+			map[string]interface{}{"name": "cache", "enabled": false},
+		},
+		"name": "api",
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		Other       Map
+		Options     []Option
+		Position    string
+		Expected    interface{}
+		Overwritten []string
+		Kept        []string
+	}{
+		{
+			Other:       Map{"server": map[string]interface{}{"port": 9090, "timeout": "5s"}},
+			Position:    "server",
+			Expected:    map[string]interface{}{"host": "localhost", "port": 9090, "tls": false, "proxy": nil, "timeout": "5s"},
+			Overwritten: []string{"server.port"},
+		},
+		{
+			Other:    Map{"server": map[string]interface{}{"port": 9090, "host": "localhost"}},
+			Options:  []Option{OnConflict(ConflictKeep)},
+			Position: "server.port",
+			Expected: 8080,
+			Kept:     []string{"server.port"},
+		},
+		{
+			Other:       Map{"server": map[string]interface{}{"proxy": "http://proxy"}},
+			Position:    "server.proxy",
+			Expected:    "http://proxy",
+			Overwritten: []string{"server.proxy"},
+		},
+		{
+			Other:       Map{"hosts": []interface{}{"c"}},
+			Position:    "hosts",
+			Expected:    []interface{}{"c"},
+			Overwritten: []string{"hosts"},
+		},
+		{
+			Other:    Map{"hosts": []interface{}{"c"}},
+			Options:  []Option{MergeSlices(SliceAppend)},
+			Position: "hosts",
+			Expected: []interface{}{"a", "b", "c"},
+		},
+		{
+			Other:       Map{"hosts": []string{"c", "b", "d"}},
+			Options:     []Option{MergeSlices(SliceMergeByIndex)},
+			Position:    "hosts",
+			Expected:    []interface{}{"c", "b", "d"},
+			Overwritten: []string{"hosts.0"},
+		},
+		{
+			Other: Map{"plugins": []interface{}{
+				map[string]interface{}{"name": "cache", "enabled": true},
+				map[string]interface{}{"name": "metrics"},
+			}},
+			Options:  []Option{MergeByKey("name")},
+			Position: "plugins",
+			Expected: []interface{}{
+				map[string]interface{}{"name": "auth", "enabled": true},
+				map[string]interface{}{"name": "cache", "enabled": true},
+				map[string]interface{}{"name": "metrics"},
+			},
+			Overwritten: []string{"plugins.1.enabled"},
+		},
+		{
+			Other:       Map{"labels": map[string]interface{}{"team": "core", "env": "prod"}},
+			Position:    "labels",
+			Expected:    map[string]interface{}{"env": "prod", "team": "core"},
+			Overwritten: []string{"labels.env"},
+		},
+		{
+			Other:       Map{"name": map[string]interface{}{"first": "api"}},
+			Position:    "name",
+			Expected:    map[string]interface{}{"first": "api"},
+			Overwritten: []string{"name"},
+		},
+		{
+			Other:    Map{"name": map[string]interface{}{"first": "api"}, "server": map[string]interface{}{"port": 1}},
+			Options:  []Option{OnTypeMismatch(ConflictKeep)},
+			Position: "name",
+			Expected: "api",
+			Kept:     []string{"name"},
+			Overwritten: []string{
+				"server.port",
+			},
+		},
+		{
+			Other:    Map{"k8s.io/name": "api", "name": "api"},
+			Position: `k8s\.io/name`,
+			Expected: "api",
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			m := defaultsConfig()
+
+			report, err := m.Merge(test.Other, test.Options...)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if actual := m.GetInterface(test.Position); !reflect.DeepEqual(test.Expected, actual) {
+				t.Errorf("[%s] expected %v, but got %v", test.Position, test.Expected, actual)
+			}
+			if !reflect.DeepEqual(test.Overwritten, report.Overwritten) {
+				t.Errorf("expected overwritten %v, but got %v", test.Overwritten, report.Overwritten)
+			}
+			if !reflect.DeepEqual(test.Kept, report.Kept) {
+				t.Errorf("expected kept %v, but got %v", test.Kept, report.Kept)
+			}
+		})
+	}
+}
+
+func TestMergeError(t *testing.T) {
+	m := defaultsConfig()
+	other := Map{"name": "api", "server": map[string]interface{}{"host": "example.com", "port": 8080}}
+
+	var conflict *ConflictError
+	if _, err := m.Merge(other, OnConflict(ConflictFail)); !errors.As(err, &conflict) || conflict.Path != "server.host" {
+		t.Errorf("expected *ConflictError for server.host, but got %v", err)
+	}
+	if !reflect.DeepEqual(defaultsConfig(), m) {
+		t.Errorf("expected map not changed, but got %v", m)
+	}
+
+	var mismatchErr *TypeMismatchError
+	if _, err := m.Merge(Map{"hosts": "a"}, OnTypeMismatch(ConflictFail)); !errors.As(err, &mismatchErr) || mismatchErr.Path != "hosts" {
+		t.Errorf("expected *TypeMismatchError for hosts, but got %v", err)
+	}
+
+	var nilMap Map
+	if _, err := nilMap.Merge(other); !errors.Is(err, ErrInvalidInputType) {
+		t.Errorf("expected ErrInvalidInputType for nil Map, but got %v", err)
+	}
+}
+
+func TestMergeCopies(t *testing.T) {
+	m := Map{}
+	other := Map{"server": map[string]interface{}{"hosts": []interface{}{"a"}}}
+
+	if _, err := m.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Set("server.hosts.0", "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	if value := other.GetString("server.hosts.0"); value != "a" {
+		t.Errorf("expected other not changed, but got %v", value)
+	}
+}
+
+func ExampleMap_Merge() {
+	config, _ := NewFromJSON(`{"server": {"host": "localhost", "port": 8080}, "hosts": ["a"]}`)
+	file, _ := NewFromJSON(`{"server": {"port": 9090}, "hosts": ["b"]}`)
+
+	report, err := config.Merge(file, MergeSlices(SliceAppend))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(config.GetInterface("server.port"), config.GetStringSlice("hosts"))
+	fmt.Println(report.Overwritten)
+	// output:
+	// 9090 [a b]
+	// [server.port]
+}
@@ -0,0 +1,98 @@
+package nested
+
+import "fmt"
+
+// MergePatch applies the JSON Merge Patch (RFC 7386) patch to Map, the maps are merged recursively,
+// the keys with null in patch are removed and any other value replaces the value of Map, slices included.
+// It returns ErrInvalidInputType if the Map is nil.
+func (m Map) MergePatch(patch Map) error {
+	if m == nil {
+		return fmt.Errorf("%w: MergePatch needs a non-nil Map", ErrInvalidInputType)
+	}
+
+	mergePatchMap(map[string]interface{}(m), patch)
+	return nil
+}
+
+// mergePatch returns the result of patch applied to target.
+func mergePatch(target, patch interface{}) interface{} {
+	if valueKind(patch) != "map" {
+		return deepCopy(patch)
+	}
+
+	dst, ok := target.(map[string]interface{})
+	if !ok {
+		dst = make(map[string]interface{})
+		if valueKind(target) == "map" {
+			for _, item := range children(target) {
+				dst[item.Path] = item.Value
+			}
+		}
+	}
+	mergePatchMap(dst, patch)
+
+	if _, ok := target.(Map); ok {
+		return Map(dst)
+	}
+	return dst
+}
+
+// mergePatchMap applies all keys of patch to dst.
+func mergePatchMap(dst map[string]interface{}, patch interface{}) {
+	for _, item := range children(patch) {
+		if item.Value == nil {
+			delete(dst, item.Path)
+			continue
+		}
+		dst[item.Path] = mergePatch(dst[item.Path], item.Value)
+	}
+}
+
+// CreateMergePatch returns the JSON Merge Patch (RFC 7386) that changes orig to updated,
+// the keys removed are null in the patch. Merge patches cannot set null values, so keys with
+// null in updated are removed by the patch.
+func CreateMergePatch(orig, updated Map) Map {
+	return Map(createMergePatch(map[string]interface{}(orig), map[string]interface{}(updated)))
+}
+
+// createMergePatch returns the patch with the keys of maps a and b that are different.
+func createMergePatch(a, b interface{}) map[string]interface{} {
+	patch := make(map[string]interface{})
+
+	valuesA := make(map[string]interface{})
+	for _, item := range children(a) {
+		valuesA[item.Path] = item.Value
+	}
+
+	valuesB := make(map[string]interface{})
+	for _, item := range children(b) {
+		valuesB[item.Path] = item.Value
+	}
+
+	for key := range valuesA {
+		if _, ok := valuesB[key]; !ok {
+			patch[key] = nil
+		}
+	}
+
+	for key, valueB := range valuesB {
+		valueA, ok := valuesA[key]
+		switch {
+		case !ok:
+			patch[key] = deepCopy(valueB)
+		case valueKind(valueA) == "map" && valueKind(valueB) == "map":
+			if child := createMergePatch(valueA, valueB); len(child) > 0 {
+				patch[key] = child
+			}
+		case !jsonEqual(valueA, valueB):
+			patch[key] = deepCopy(valueB)
+		}
+	}
+
+	return patch
+}
+
+// MergePatch is helper for function MergePatch from Map.
+func MergePatch(mapper, patch map[string]interface{}) error {
+	return New(mapper).MergePatch(patch)
+}
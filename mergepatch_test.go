@@ -0,0 +1,114 @@
+package nested
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	// examples of the appendix A of RFC 7386.
+	tests := []struct {
+		Target   string
+		Patch    string
+		Expected string
+	}{
+		{Target: `{"a":"b"}`, Patch: `{"a":"c"}`, Expected: `{"a":"c"}`},
+		{Target: `{"a":"b"}`, Patch: `{"b":"c"}`, Expected: `{"a":"b","b":"c"}`},
+		{Target: `{"a":"b"}`, Patch: `{"a":null}`, Expected: `{}`},
+		{Target: `{"a":"b","b":"c"}`, Patch: `{"a":null}`, Expected: `{"b":"c"}`},
+		{Target: `{"a":["b"]}`, Patch: `{"a":"c"}`, Expected: `{"a":"c"}`},
+		{Target: `{"a":"c"}`, Patch: `{"a":["b"]}`, Expected: `{"a":["b"]}`},
+		{Target: `{"a":{"b":"c"}}`, Patch: `{"a":{"b":"d","c":null}}`, Expected: `{"a":{"b":"d"}}`},
+		{Target: `{"a":[{"b":"c"}]}`, Patch: `{"a":[1]}`, Expected: `{"a":[1]}`},
+		{Target: `{"e":null}`, Patch: `{"a":1}`, Expected: `{"e":null,"a":1}`},
+		{Target: `{"a":"foo"}`, Patch: `{"a":{"bb":{"ccc":null}}}`, Expected: `{"a":{"bb":{}}}`},
+		{Target: `{}`, Patch: `{"a":{"bb":{"ccc":null}}}`, Expected: `{"a":{"bb":{}}}`},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			target, _ := NewFromJSON(test.Target)
+			patch, _ := NewFromJSON(test.Patch)
+			expected, _ := NewFromJSON(test.Expected)
+
+			if err := target.MergePatch(patch); err != nil {
+				t.Fatalf("expected error nil, but got %v", err)
+			}
+			if !reflect.DeepEqual(expected, target) {
+				t.Errorf("expected %v, but got %v", expected, target)
+			}
+		})
+	}
+}
+
+func TestMergePatchTypedMaps(t *testing.T) {
+	m := Map{"labels": map[string]string{"env": "dev", "team": "core"}}
+
+	m.MergePatch(Map{"labels": map[string]interface{}{"env": "prod", "team": nil}})
+
+	expected := map[string]interface{}{"env": "prod"}
+	if actual := m.GetInterface("labels"); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
+
+func TestMergePatchNil(t *testing.T) {
+	var m Map
+	if err := m.MergePatch(Map{"a": 1}); !errors.Is(err, ErrInvalidInputType) {
+		t.Errorf("expected ErrInvalidInputType for nil Map, but got %v", err)
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	tests := []struct {
+		Orig     string
+		Updated  string
+		Expected string
+		Applied  string
+	}{
+		{Orig: `{"a":"b"}`, Updated: `{"a":"b"}`, Expected: `{}`},
+		{Orig: `{"a":"b"}`, Updated: `{"a":"c"}`, Expected: `{"a":"c"}`},
+		{Orig: `{"a":"b","b":"c"}`, Updated: `{"b":"c"}`, Expected: `{"a":null}`},
+		{Orig: `{"a":{"b":"c","d":1}}`, Updated: `{"a":{"b":"c","d":2,"e":true}}`, Expected: `{"a":{"d":2,"e":true}}`},
+		{Orig: `{"a":{"b":"c"}}`, Updated: `{"a":["b"]}`, Expected: `{"a":["b"]}`},
+		{Orig: `{"a":[1,2]}`, Updated: `{"a":[1]}`, Expected: `{"a":[1]}`},
+		{Orig: `{"a":"b"}`, Updated: `{"a":null}`, Expected: `{"a":null}`, Applied: `{}`},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			orig, _ := NewFromJSON(test.Orig)
+			updated, _ := NewFromJSON(test.Updated)
+			expected, _ := NewFromJSON(test.Expected)
+
+			patch := CreateMergePatch(orig, updated)
+			if !reflect.DeepEqual(expected, patch) {
+				t.Fatalf("expected %v, but got %v", expected, patch)
+			}
+
+			if test.Applied != "" {
+				updated, _ = NewFromJSON(test.Applied)
+			}
+
+			orig.MergePatch(patch)
+			if !reflect.DeepEqual(updated, orig) {
+				t.Errorf("expected %v after the patch, but got %v", updated, orig)
+			}
+		})
+	}
+}
+
+func ExampleMap_MergePatch() {
+	m, _ := NewFromJSON(`{"title": "Goodbye!", "author": {"givenName": "John", "familyName": "Doe"}, "tags": ["example", "sample"]}`)
+	patch, _ := NewFromJSON(`{"title": "Hello!", "author": {"familyName": null}, "tags": ["example"]}`)
+
+	m.MergePatch(patch)
+
+	b, _ := json.Marshal(m)
+	fmt.Println(string(b))
+	// output:
+	// {"author":{"givenName":"John"},"tags":["example"],"title":"Hello!"}
+}
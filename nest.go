@@ -3,7 +3,6 @@ package nested
 import (
 	"encoding/json"
 	"errors"
-	"strings"
 	"time"
 )
 
@@ -22,22 +21,27 @@ func New(in map[string]interface{}) Map {
 	return Map(in)
 }
 
-// NewFromJSON returns new Map instance when in is a json valid
+// NewFromJSON returns new Map instance when in is a json valid,
+// it returns *ParseError with the error of json that is also ErrInvalidInputType.
 func NewFromJSON(in string) (Map, error) {
 	var m Map
 	if err := json.Unmarshal([]byte(in), &m); err != nil {
-		return nil, ErrInvalidInputType
+		return nil, &ParseError{Layout: "json", Err: err}
 	}
 	return m, nil
 }
 
-// NewFromInterface return new map instance if can cast input to map[string]interface{}.
-func NewFromInterface(in interface{}) (Map, error) {
-	if m, ok := in.(map[string]interface{}); ok {
+// NewFromInterface return new map instance if can cast input to map[string]interface{},
+// structs, pointers to struct and other maps are converted like NewFromStruct does.
+func NewFromInterface(in interface{}, opts ...Option) (Map, error) {
+	switch m := in.(type) {
+	case map[string]interface{}:
+		return New(m), nil
+	case Map:
 		return New(m), nil
 	}
 
-	return nil, ErrInvalidInputType
+	return NewFromStruct(in, opts...)
 }
 
 // GetInterface returns the interface value from position that you passed by argument
@@ -47,32 +51,19 @@ func (m Map) GetInterface(position string) interface{} {
 }
 
 // Interface returns the value from position that you pass separately by . (dot)
+// numeric segments (phones.0) and brackets (phones[0], phones[-1]) are used as index of slices.
+// positions that start with / (slash) are JSON Pointers like /advert/contact/phones/0, see Pointer.
 // the first value is a value that you are looking for and second is bool if found the field or not
-// if the field is not found it returns nil and false.
-func (m Map) Interface(position string) (interface{}, bool) {
-	pos := strings.Split(position, ".")
-
-	t := m
-	for key, posKey := range pos {
-		v, ok := t[posKey]
-		if !ok {
-			break
-		}
-
-		if newValue, ok := v.(map[string]interface{}); ok {
-			if key+1 == len(pos) {
-				return v, true
-			}
-
-			t = newValue
-		} else if newString, ok := v.(string); ok {
-			return newString, true
-		} else if newInt, ok := v.(int); ok {
-			return newInt, true
-		}
+// if the field is not found it returns nil and false, see Strict to return values of positions with more segments.
+func (m Map) Interface(position string, opts ...Option) (interface{}, bool) {
+	var buf [8]segment
+	pos := appendPosition(buf[:0], position)
+	if pos == nil {
+		return nil, false
 	}
 
-	return nil, false
+	value, missing := walk(map[string]interface{}(m), pos, newOptions(opts).loose)
+	return value, missing < 0
 }
 
 // GetString returns the string value from position that you passed by argument
@@ -83,76 +74,86 @@ func (m Map) GetString(position string) string {
 
 // String returns the string value from position that you passed by argument and a bool if found the field.
 // if it doesn't find the field the returns is "" and false.
-func (m Map) String(position string) (value string, ok bool) {
-	var valueTmp interface{}
-	if valueTmp, ok = m.Interface(position); !ok {
-		return "", false
-	}
-	if value, ok = valueTmp.(string); !ok {
-		return "", false
-	}
-	return value, true
+func (m Map) String(position string) (string, bool) {
+	value, err := m.StringE(position)
+	return value, err == nil
 }
 
 // GetInt returns the int value from position that you passed by argument
-func (m Map) GetInt(position string) int {
-	value, _ := m.Int(position)
+func (m Map) GetInt(position string, opts ...Option) int {
+	value, _ := m.Int(position, opts...)
 	return value
 }
 
 // Int returns the int value from position that you passed by argument and a bool if found the field.
-// if it doesn't find the field the returns is 0 and false.
-func (m Map) Int(position string) (value int, ok bool) {
-	var valueTmp interface{}
-	if valueTmp, ok = m.Interface(position); !ok {
-		return 0, false
-	}
-	if value, ok = valueTmp.(int); !ok {
-		return 0, false
-	}
-	return value, true
+// any integer or float type and json.Number are converted, numeric strings are converted with Lenient option.
+// if it doesn't find the field or the number doesn't fit in an int the returns is 0 and false.
+func (m Map) Int(position string, opts ...Option) (int, bool) {
+	value, err := m.IntE(position, opts...)
+	return value, err == nil
+}
+
+// GetInt64 returns the int64 value from position that you passed by argument
+func (m Map) GetInt64(position string, opts ...Option) int64 {
+	value, _ := m.Int64(position, opts...)
+	return value
+}
+
+// Int64 returns the int64 value from position that you passed by argument and a bool if found the field.
+// any integer or float type and json.Number are converted, numeric strings are converted with Lenient option.
+// if it doesn't find the field or the number doesn't fit in an int64 the returns is 0 and false.
+func (m Map) Int64(position string, opts ...Option) (int64, bool) {
+	value, err := m.Int64E(position, opts...)
+	return value, err == nil
+}
+
+// GetUint returns the uint value from position that you passed by argument
+func (m Map) GetUint(position string, opts ...Option) uint {
+	value, _ := m.Uint(position, opts...)
+	return value
+}
+
+// Uint returns the uint value from position that you passed by argument and a bool if found the field.
+// any integer or float type and json.Number are converted, numeric strings are converted with Lenient option.
+// if it doesn't find the field or the number doesn't fit in an uint the returns is 0 and false.
+func (m Map) Uint(position string, opts ...Option) (uint, bool) {
+	value, err := m.UintE(position, opts...)
+	return value, err == nil
+}
+
+// GetUint64 returns the uint64 value from position that you passed by argument
+func (m Map) GetUint64(position string, opts ...Option) uint64 {
+	value, _ := m.Uint64(position, opts...)
+	return value
+}
+
+// Uint64 returns the uint64 value from position that you passed by argument and a bool if found the field.
+// any integer or float type and json.Number are converted, numeric strings are converted with Lenient option.
+// if it doesn't find the field or the number doesn't fit in an uint64 the returns is 0 and false.
+func (m Map) Uint64(position string, opts ...Option) (uint64, bool) {
+	value, err := m.Uint64E(position, opts...)
+	return value, err == nil
 }
 
 // GetTime returns the time value from position that you passed by argument
-func (m Map) GetTime(position, layout string) time.Time {
-	value, _ := m.Time(position, layout)
+func (m Map) GetTime(position, layout string, opts ...Option) time.Time {
+	value, _ := m.Time(position, layout, opts...)
 	return value
 }
 
 // Time returns the time.Time value from position that you passed by argument and a bool if found the field.
 // if it doesn't find the field the returns is time.Time default and false.
 // By default the layout is time.RFC3339, you can change the layout using a new one as second parameter
-func (m Map) Time(position, layout string) (value time.Time, ok bool) {
-	var valueTmp string
-	if valueTmp, ok = m.String(position); !ok {
-		return time.Time{}, false
-	}
-
-	if layout == "" {
-		layout = time.RFC3339
-	}
-
-	var err error
-	if value, err = time.Parse(layout, valueTmp); err != nil {
-		return time.Time{}, false
-	}
-
-	return value, true
+// and use the options Layouts, Epoch and Location for other layouts, Unix epochs and time zones.
+func (m Map) Time(position, layout string, opts ...Option) (time.Time, bool) {
+	value, err := m.TimeE(position, layout, opts...)
+	return value, err == nil
 }
 
 // SubFromString return Map from string json format if json is valid.
 func (m Map) SubFromString(position string) (Map, bool) {
-	subData, ok := m.String(position)
-	if !ok {
-		return nil, false
-	}
-
-	var value Map
-	if err := json.Unmarshal([]byte(subData), &value); err != nil {
-		return nil, false
-	}
-
-	return value, true
+	value, err := m.SubFromStringE(position)
+	return value, err == nil
 }
 
 // GetSubFromString returns the time value from position that you passed by argument
@@ -161,9 +162,90 @@ func (m Map) GetSubFromString(position string) Map {
 	return value
 }
 
+// GetFloat64 returns the float64 value from position that you passed by argument
+func (m Map) GetFloat64(position string, opts ...Option) float64 {
+	value, _ := m.Float64(position, opts...)
+	return value
+}
+
+// Float64 returns the float64 value from position that you passed by argument and a bool if found the field.
+// any integer or float type and json.Number are converted, numeric strings are converted with Lenient option.
+// if it doesn't find the field the returns is 0 and false.
+func (m Map) Float64(position string, opts ...Option) (float64, bool) {
+	value, err := m.Float64E(position, opts...)
+	return value, err == nil
+}
+
+// GetBool returns the bool value from position that you passed by argument
+func (m Map) GetBool(position string) bool {
+	value, _ := m.Bool(position)
+	return value
+}
+
+// Bool returns the bool value from position that you passed by argument and a bool if found the field.
+// if it doesn't find the field the returns is false and false.
+func (m Map) Bool(position string) (bool, bool) {
+	value, err := m.BoolE(position)
+	return value, err == nil
+}
+
+// GetSlice returns the slice value from position that you passed by argument
+func (m Map) GetSlice(position string) []interface{} {
+	value, _ := m.Slice(position)
+	return value
+}
+
+// Slice returns the slice value from position that you passed by argument and a bool if found the field.
+// typed slices like []string are copied to a []interface{}.
+// if it doesn't find the field the returns is nil and false.
+func (m Map) Slice(position string) ([]interface{}, bool) {
+	value, err := m.SliceE(position)
+	return value, err == nil
+}
+
+// GetStringSlice returns the []string value from position that you passed by argument
+func (m Map) GetStringSlice(position string) []string {
+	value, _ := m.StringSlice(position)
+	return value
+}
+
+// StringSlice returns the []string value from position that you passed by argument and a bool if found the field.
+// if it doesn't find the field or some element is not a string the returns is nil and false.
+func (m Map) StringSlice(position string) ([]string, bool) {
+	value, err := m.StringSliceE(position)
+	return value, err == nil
+}
+
+// GetIntSlice returns the []int value from position that you passed by argument
+func (m Map) GetIntSlice(position string, opts ...Option) []int {
+	value, _ := m.IntSlice(position, opts...)
+	return value
+}
+
+// IntSlice returns the []int value from position that you passed by argument and a bool if found the field.
+// the elements are converted like the function Int does.
+// if it doesn't find the field or some element is not an int the returns is nil and false.
+func (m Map) IntSlice(position string, opts ...Option) ([]int, bool) {
+	value, err := m.IntSliceE(position, opts...)
+	return value, err == nil
+}
+
+// GetMapSlice returns the []Map value from position that you passed by argument
+func (m Map) GetMapSlice(position string) []Map {
+	value, _ := m.MapSlice(position)
+	return value
+}
+
+// MapSlice returns the []Map value from position that you passed by argument and a bool if found the field.
+// if it doesn't find the field or some element is not a map[string]interface{} the returns is nil and false.
+func (m Map) MapSlice(position string) ([]Map, bool) {
+	value, err := m.MapSliceE(position)
+	return value, err == nil
+}
+
 // Interface is helper for function Interface from Map.
-func Interface(position string, mapper map[string]interface{}) (interface{}, bool) {
-	return New(mapper).Interface(position)
+func Interface(position string, mapper map[string]interface{}, opts ...Option) (interface{}, bool) {
+	return New(mapper).Interface(position, opts...)
 }
 
 // GetInterface is helper for function GetInterface from Map.
@@ -182,23 +264,53 @@ func GetString(position string, mapper map[string]interface{}) string {
 }
 
 // Int is helper for function Int from Map.
-func Int(position string, mapper map[string]interface{}) (int, bool) {
-	return New(mapper).Int(position)
+func Int(position string, mapper map[string]interface{}, opts ...Option) (int, bool) {
+	return New(mapper).Int(position, opts...)
 }
 
 // GetInt is helper for function GetInt from Map.
-func GetInt(position string, mapper map[string]interface{}) int {
-	return New(mapper).GetInt(position)
+func GetInt(position string, mapper map[string]interface{}, opts ...Option) int {
+	return New(mapper).GetInt(position, opts...)
+}
+
+// Int64 is helper for function Int64 from Map.
+func Int64(position string, mapper map[string]interface{}, opts ...Option) (int64, bool) {
+	return New(mapper).Int64(position, opts...)
+}
+
+// GetInt64 is helper for function GetInt64 from Map.
+func GetInt64(position string, mapper map[string]interface{}, opts ...Option) int64 {
+	return New(mapper).GetInt64(position, opts...)
+}
+
+// Uint is helper for function Uint from Map.
+func Uint(position string, mapper map[string]interface{}, opts ...Option) (uint, bool) {
+	return New(mapper).Uint(position, opts...)
+}
+
+// GetUint is helper for function GetUint from Map.
+func GetUint(position string, mapper map[string]interface{}, opts ...Option) uint {
+	return New(mapper).GetUint(position, opts...)
+}
+
+// Uint64 is helper for function Uint64 from Map.
+func Uint64(position string, mapper map[string]interface{}, opts ...Option) (uint64, bool) {
+	return New(mapper).Uint64(position, opts...)
+}
+
+// GetUint64 is helper for function GetUint64 from Map.
+func GetUint64(position string, mapper map[string]interface{}, opts ...Option) uint64 {
+	return New(mapper).GetUint64(position, opts...)
 }
 
 // Time is helper for function Time from Map.
-func Time(position string, mapper map[string]interface{}, layout string) (time.Time, bool) {
-	return New(mapper).Time(position, layout)
+func Time(position string, mapper map[string]interface{}, layout string, opts ...Option) (time.Time, bool) {
+	return New(mapper).Time(position, layout, opts...)
 }
 
 // GetTime is helper for function GetTime from Map.
-func GetTime(position string, mapper map[string]interface{}, layout string) time.Time {
-	return New(mapper).GetTime(position, layout)
+func GetTime(position string, mapper map[string]interface{}, layout string, opts ...Option) time.Time {
+	return New(mapper).GetTime(position, layout, opts...)
 }
 
 // SubFromString is helper for function SubFromString from Map.
@@ -210,3 +322,63 @@ func SubFromString(position string, mapper map[string]interface{}) (Map, bool) {
 func GetSubFromString(position string, mapper map[string]interface{}) Map {
 	return New(mapper).GetSubFromString(position)
 }
+
+// Float64 is helper for function Float64 from Map.
+func Float64(position string, mapper map[string]interface{}, opts ...Option) (float64, bool) {
+	return New(mapper).Float64(position, opts...)
+}
+
+// GetFloat64 is helper for function GetFloat64 from Map.
+func GetFloat64(position string, mapper map[string]interface{}, opts ...Option) float64 {
+	return New(mapper).GetFloat64(position, opts...)
+}
+
+// Bool is helper for function Bool from Map.
+func Bool(position string, mapper map[string]interface{}) (bool, bool) {
+	return New(mapper).Bool(position)
+}
+
+// GetBool is helper for function GetBool from Map.
+func GetBool(position string, mapper map[string]interface{}) bool {
+	return New(mapper).GetBool(position)
+}
+
+// Slice is helper for function Slice from Map.
+func Slice(position string, mapper map[string]interface{}) ([]interface{}, bool) {
+	return New(mapper).Slice(position)
+}
+
+// GetSlice is helper for function GetSlice from Map.
+func GetSlice(position string, mapper map[string]interface{}) []interface{} {
+	return New(mapper).GetSlice(position)
+}
+
+// StringSlice is helper for function StringSlice from Map.
+func StringSlice(position string, mapper map[string]interface{}) ([]string, bool) {
+	return New(mapper).StringSlice(position)
+}
+
+// GetStringSlice is helper for function GetStringSlice from Map.
+func GetStringSlice(position string, mapper map[string]interface{}) []string {
+	return New(mapper).GetStringSlice(position)
+}
+
+// IntSlice is helper for function IntSlice from Map.
+func IntSlice(position string, mapper map[string]interface{}, opts ...Option) ([]int, bool) {
+	return New(mapper).IntSlice(position, opts...)
+}
+
+// GetIntSlice is helper for function GetIntSlice from Map.
+func GetIntSlice(position string, mapper map[string]interface{}, opts ...Option) []int {
+	return New(mapper).GetIntSlice(position, opts...)
+}
+
+// MapSlice is helper for function MapSlice from Map.
+func MapSlice(position string, mapper map[string]interface{}) ([]Map, bool) {
+	return New(mapper).MapSlice(position)
+}
+
+// GetMapSlice is helper for function GetMapSlice from Map.
+func GetMapSlice(position string, mapper map[string]interface{}) []Map {
+	return New(mapper).GetMapSlice(position)
+}
@@ -1,6 +1,8 @@
 package nested
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -13,10 +15,14 @@ var data = map[string]interface{}{
 	"advert": map[string]interface{}{
 		"id":    "12",
 		"title": "Lorem Ipsum",
+		"price": 19.9,
+		"tags":  []interface{}{"new", "sale"},
+		"rates": []interface{}{5, 4},
 		"status": map[string]interface{}{
-			"code": "active",
-			"url":  "www.loremipsum.com",
-			"ttl":  123123,
+			"code":   "active",
+			"url":    "www.loremipsum.com",
+			"ttl":    123123,
+			"active": true,
 		},
 		"contact": map[string]interface{}{
 			"name": "daniel3",
@@ -29,6 +35,17 @@ var data = map[string]interface{}{
 			"date_time": "1987-01-29T19:00:00Z",
 			"birth":     "29/01/1987",
 		},
+		"images": []interface{}{
+			map[string]interface{}{
+				"url":     "www.loremipsum.com/1.jpg",
+				"order":   1,
+				"created": "1987-01-29T19:00:00Z",
+			},
+			map[string]interface{}{
+				"url":   "www.loremipsum.com/2.jpg",
+				"order": 2,
+			},
+		},
 		"extras":       "{\"lorem_ipsum\":{\"url\":\"www.lorem-ipsum.com\",\"id\":12},\"lorem_bacon\":{\"url\":\"www.lorem_bacon.com\",\"id\":\"da9883jw32dl12j120un9sa87ds5asn\"}}",
 		"extras_error": "{\"lorem_ipsu:\"www.lorem_bacon.com\",\"id\":\"da9883jw32dl12j120un9sa87ds5asn\"}}",
 	},
@@ -156,6 +173,133 @@ func TestInterface(t *testing.T) {
 			ExpectedSecond: false,
 			Data:           map[string]interface{}{},
 		},
+		{
+			Parameter:      "advert.contact.phones.0",
+			ExpectedFirst:  "790123123",
+			ExpectedSecond: true,
+			Data:           data,
+		},
+		{
+			Parameter:      "advert.contact.phones[1]",
+			ExpectedFirst:  "790123546",
+			ExpectedSecond: true,
+			Data:           data,
+		},
+		{
+			Parameter:      "advert.contact.phones[-1]",
+			ExpectedFirst:  "790123546",
+			ExpectedSecond: true,
+			Data:           data,
+		},
+		{
+			Parameter:      "advert.contact.phones.2",
+			ExpectedFirst:  nil,
+			ExpectedSecond: false,
+			Data:           data,
+		},
+		{
+			Parameter:      "advert.contact.phones[-3]",
+			ExpectedFirst:  nil,
+			ExpectedSecond: false,
+			Data:           data,
+		},
+		{
+			Parameter:      "advert.contact.phones.first",
+			ExpectedFirst:  nil,
+			ExpectedSecond: false,
+			Data:           data,
+		},
+		{
+			Parameter:      "advert.contact.phones[0",
+			ExpectedFirst:  nil,
+			ExpectedSecond: false,
+			Data:           data,
+		},
+		{
+			Parameter: "advert.images[1]",
+			ExpectedFirst: map[string]interface{}{
+				"url":   "www.loremipsum.com/2.jpg",
+				"order": 2,
+			},
+			ExpectedSecond: true,
+			Data:           data,
+		},
+		{
+			Parameter:      "advert.images.0.url",
+			ExpectedFirst:  "www.loremipsum.com/1.jpg",
+			ExpectedSecond: true,
+			Data:           data,
+		},
+		{
+			Parameter:      "advert.contact.phones",
+			ExpectedFirst:  []string{"790123123", "790123546"},
+			ExpectedSecond: true,
+			Data:           data,
+		},
+		{
+			Parameter:      "advert.status.active",
+			ExpectedFirst:  true,
+			ExpectedSecond: true,
+			Data:           data,
+		},
+		{
+			Parameter:      "advert.price",
+			ExpectedFirst:  19.9,
+			ExpectedSecond: true,
+			Data:           data,
+		},
+		{
+			Parameter:      "advert.nickname",
+			ExpectedFirst:  nil,
+			ExpectedSecond: true,
+			Data:           map[string]interface{}{"advert": map[string]interface{}{"nickname": nil}},
+		},
+		{
+			Parameter:      "config.name",
+			ExpectedFirst:  "nested",
+			ExpectedSecond: true,
+			Data:           map[string]interface{}{"config": Map{"name": "nested"}},
+		},
+		{
+			Parameter:      "labels.app",
+			ExpectedFirst:  "nested",
+			ExpectedSecond: true,
+			Data:           map[string]interface{}{"labels": map[string]string{"app": "nested"}},
+		},
+		{
+			Parameter:      "yaml.person.name",
+			ExpectedFirst:  "Rodrigo",
+			ExpectedSecond: true,
+			Data: map[string]interface{}{"yaml": map[interface{}]interface{}{
+				"person": map[interface{}]interface{}{"name": "Rodrigo"},
+			}},
+		},
+		{
+			Parameter:      "yaml.1.name",
+			ExpectedFirst:  "Rodrigo",
+			ExpectedSecond: true,
+			Data: map[string]interface{}{"yaml": map[interface{}]interface{}{
+				1: map[interface{}]interface{}{"name": "Rodrigo"},
+			}},
+		},
+		{
+			Parameter:      "levels.3",
+			ExpectedFirst:  "senior",
+			ExpectedSecond: true,
+			Data:           map[string]interface{}{"levels": map[int]string{3: "senior"}},
+		},
+		{
+			Parameter:      "levels.senior",
+			ExpectedFirst:  nil,
+			ExpectedSecond: false,
+			Data:           map[string]interface{}{"levels": map[int]string{3: "senior"}},
+		},
+		{
+			Parameter:      "matrix[1][0]",
+			ExpectedFirst:  3,
+			ExpectedSecond: true,
+			Data:           map[string]interface{}{"matrix": [][]int{{1, 2}, {3, 4}}},
+		},
 	}
 
 	for key, test := range tests {
@@ -203,6 +347,89 @@ func ExampleMap_Interface() {
 	fmt.Println(session, found)
 	// output: map[token:62vsy29v8y4v248v5y97v1e21v35ce97] true
 }
+func ExampleMap_Interface_slice() {
+	data := map[string]interface{}{
+		"contact": map[string]interface{}{
+			"phones": []string{"473-68-42", "789-52-84"},
+		},
+	}
+
+	first, found := New(data).Interface("contact.phones.0")
+	fmt.Println(first, found)
+
+	last, found := New(data).Interface("contact.phones[-1]")
+	fmt.Println(last, found)
+	// output:
+	// 473-68-42 true
+	// 789-52-84 true
+}
+func TestInterfaceStrict(t *testing.T) {
+	strict := map[string]interface{}{
+		"session": map[string]interface{}{
+			"token":   "62vsy29v8y4v248v5y97v1e21v35ce97",
+			"expire":  1581627723,
+			"user":    nil,
+			"scopes":  []interface{}{"read", "write"},
+			"devices": []interface{}{map[string]interface{}{"id": "d1"}},
+		},
+	}
+
+	tests := []struct {
+		Parameter      string
+		Options        []Option
+		ExpectedFirst  interface{}
+		ExpectedSecond bool
+		Missing        string
+	}{
+		{Parameter: "session.token", ExpectedFirst: "62vsy29v8y4v248v5y97v1e21v35ce97", ExpectedSecond: true},
+		{Parameter: "session.token.extra", ExpectedFirst: nil, ExpectedSecond: false, Missing: "extra"},
+		{Parameter: "session.token.extra.more", ExpectedFirst: nil, ExpectedSecond: false, Missing: "extra"},
+		{Parameter: "session.expire.0", ExpectedFirst: nil, ExpectedSecond: false, Missing: "0"},
+		{Parameter: "session.user.name", ExpectedFirst: nil, ExpectedSecond: false, Missing: "name"},
+		{Parameter: "session.scopes.0.name", ExpectedFirst: nil, ExpectedSecond: false, Missing: "name"},
+		{Parameter: "session.devices.0.id", ExpectedFirst: "d1", ExpectedSecond: true},
+		{Parameter: "session.devices.1.id", ExpectedFirst: nil, ExpectedSecond: false, Missing: "1"},
+		{Parameter: "session.missing.token", ExpectedFirst: nil, ExpectedSecond: false, Missing: "missing"},
+		{Parameter: "missing.session.token", ExpectedFirst: nil, ExpectedSecond: false, Missing: "missing"},
+		{Parameter: "/session/token/extra", ExpectedFirst: nil, ExpectedSecond: false, Missing: "extra"},
+		{Parameter: "session.token.extra", Options: []Option{Strict(true)}, ExpectedFirst: nil, ExpectedSecond: false, Missing: "extra"},
+		{Parameter: "session.token.extra", Options: []Option{Strict(false)}, ExpectedFirst: "62vsy29v8y4v248v5y97v1e21v35ce97", ExpectedSecond: true},
+		{Parameter: "session.scopes.1.name", Options: []Option{Strict(false)}, ExpectedFirst: "write", ExpectedSecond: true},
+		{Parameter: "session.missing.token", Options: []Option{Strict(false)}, ExpectedFirst: nil, ExpectedSecond: false, Missing: "missing"},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actualFirst, actualSecond := Interface(test.Parameter, strict, test.Options...)
+			if actualSecond != test.ExpectedSecond {
+				t.Errorf("[%s] expected found %v, but got %v", test.Parameter, test.ExpectedSecond, actualSecond)
+			}
+			if !reflect.DeepEqual(test.ExpectedFirst, actualFirst) {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.ExpectedFirst, actualFirst)
+			}
+
+			if lookup, found := MustCompile(test.Parameter).Lookup(strict, test.Options...); found != test.ExpectedSecond || !reflect.DeepEqual(test.ExpectedFirst, lookup) {
+				t.Errorf("[%s] expected Lookup %v %v, but got %v %v", test.Parameter, test.ExpectedFirst, test.ExpectedSecond, lookup, found)
+			}
+
+			_, err := InterfaceE(test.Parameter, strict, test.Options...)
+			var notFound *PathNotFoundError
+			if test.ExpectedSecond != (err == nil) {
+				t.Errorf("[%s] unexpected error %v", test.Parameter, err)
+			} else if err != nil && (!errors.As(err, &notFound) || notFound.MissingSegment != test.Missing) {
+				t.Errorf("[%s] expected missing segment %q, but got %v", test.Parameter, test.Missing, err)
+			}
+		})
+	}
+
+	if _, found := New(strict).String("session.token.extra"); found {
+		t.Error("expected String not to find session.token.extra")
+	}
+	if value, found := Get[string](New(strict), "session.token.extra", Strict(false)); !found || value == "" {
+		t.Errorf("expected Get with Strict(false) to find session.token, but got %q", value)
+	}
+}
+
 func BenchmarkInterface(b *testing.B) {
 	total := 10
 
@@ -220,6 +447,44 @@ func BenchmarkInterface(b *testing.B) {
 	}
 }
 
+func BenchmarkPathLookup(b *testing.B) {
+	total := 10
+	path := MustCompile("advert.status.ttl")
+
+	bench := make([]Map, total)
+	for i := 0; i < total; i++ {
+		bench[i] = randomData()
+	}
+
+	for n := 0; n < total; n++ {
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				path.Lookup(bench[n])
+			}
+		})
+	}
+}
+
+func BenchmarkMapInterface(b *testing.B) {
+	m := New(data)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Interface("advert.contact.name")
+	}
+}
+
+func BenchmarkPathString(b *testing.B) {
+	m := New(data)
+	path := MustCompile("advert.contact.name")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		path.String(m)
+	}
+}
+
 func TestGetInterface(t *testing.T) {
 	tests := []struct {
 		Parameter     string
@@ -361,6 +626,36 @@ func TestInt(t *testing.T) {
 			ExpectedSecond: false,
 			Data:           data,
 		},
+		{
+			Parameter:      "advert.images[-1].order",
+			ExpectedFirst:  2,
+			ExpectedSecond: true,
+			Data:           data,
+		},
+		{
+			Parameter:      "person.level",
+			ExpectedFirst:  3,
+			ExpectedSecond: true,
+			Data:           map[string]interface{}{"person": map[string]interface{}{"level": float64(3)}},
+		},
+		{
+			Parameter:      "person.level",
+			ExpectedFirst:  3,
+			ExpectedSecond: true,
+			Data:           map[string]interface{}{"person": map[string]interface{}{"level": int64(3)}},
+		},
+		{
+			Parameter:      "person.level",
+			ExpectedFirst:  3,
+			ExpectedSecond: true,
+			Data:           map[string]interface{}{"person": map[string]interface{}{"level": json.Number("3")}},
+		},
+		{
+			Parameter:      "person.level",
+			ExpectedFirst:  0,
+			ExpectedSecond: false,
+			Data:           map[string]interface{}{"person": map[string]interface{}{"level": 3.5}},
+		},
 	}
 
 	for key, test := range tests {
@@ -402,6 +697,22 @@ func ExampleMap_Int() {
 	fmt.Println(level, found)
 	// output: 3 true
 }
+func ExampleMap_Int_lenient() {
+	data := map[string]interface{}{
+		"person": map[string]interface{}{
+			"level": "3",
+		},
+	}
+
+	level, found := New(data).Int("person.level")
+	fmt.Println(level, found)
+
+	level, found = New(data).Int("person.level", Lenient())
+	fmt.Println(level, found)
+	// output:
+	// 0 false
+	// 3 true
+}
 func BenchmarkInt(b *testing.B) {
 	total := 10
 
@@ -527,6 +838,12 @@ func TestString(t *testing.T) {
 			ExpectedSecond: false,
 			Data:           data,
 		},
+		{
+			Parameter:      "advert.contact.phones[0]",
+			ExpectedFirst:  "790123123",
+			ExpectedSecond: true,
+			Data:           data,
+		},
 	}
 
 	for key, test := range tests {
@@ -707,6 +1024,13 @@ func TestTime(t *testing.T) {
 			ExpectedSecond: false,
 			Data:           data,
 		},
+		{
+			Layout:         time.RFC3339,
+			Parameter:      "advert.images.0.created",
+			ExpectedFirst:  538945200000000000,
+			ExpectedSecond: true,
+			Data:           data,
+		},
 	}
 
 	for key, test := range tests {
@@ -1020,6 +1344,296 @@ func BenchmarkGetSubFromString(b *testing.B) {
 	}
 }
 
+func TestFloat64(t *testing.T) {
+	tests := []struct {
+		Parameter      string
+		ExpectedFirst  float64
+		ExpectedSecond bool
+	}{
+		{Parameter: "advert.price", ExpectedFirst: 19.9, ExpectedSecond: true},
+		{Parameter: "advert.status.ttl", ExpectedFirst: 123123, ExpectedSecond: true},
+		{Parameter: "advert.id", ExpectedFirst: 0, ExpectedSecond: false},
+		{Parameter: "advert.bananas", ExpectedFirst: 0, ExpectedSecond: false},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, result := Float64(test.Parameter, data)
+			if actual != test.ExpectedFirst || result != test.ExpectedSecond {
+				t.Errorf("[%s] expected param1: %v and param2: %v, but got param1: %v and param2: %v",
+					test.Parameter, test.ExpectedFirst, test.ExpectedSecond, actual, result)
+			}
+			if actual := GetFloat64(test.Parameter, data); actual != test.ExpectedFirst {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.ExpectedFirst, actual)
+			}
+		})
+	}
+}
+func ExampleMap_Float64() {
+	data, _ := NewFromJSON(`{"product": {"price": 19.9}}`)
+
+	price, found := data.Float64("product.price")
+	fmt.Println(price, found)
+	// output: 19.9 true
+}
+
+func TestInt64(t *testing.T) {
+	mapper := map[string]interface{}{
+		"ttl":      int64(1533657456135539726),
+		"float":    1e19,
+		"string":   "1533657456135539726",
+		"negative": -1,
+	}
+
+	tests := []struct {
+		Parameter      string
+		Options        []Option
+		ExpectedFirst  int64
+		ExpectedSecond bool
+	}{
+		{Parameter: "ttl", ExpectedFirst: 1533657456135539726, ExpectedSecond: true},
+		{Parameter: "float", ExpectedFirst: 0, ExpectedSecond: false},
+		{Parameter: "string", ExpectedFirst: 0, ExpectedSecond: false},
+		{Parameter: "string", Options: []Option{Lenient()}, ExpectedFirst: 1533657456135539726, ExpectedSecond: true},
+		{Parameter: "negative", ExpectedFirst: -1, ExpectedSecond: true},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, result := Int64(test.Parameter, mapper, test.Options...)
+			if actual != test.ExpectedFirst || result != test.ExpectedSecond {
+				t.Errorf("[%s] expected param1: %v and param2: %v, but got param1: %v and param2: %v",
+					test.Parameter, test.ExpectedFirst, test.ExpectedSecond, actual, result)
+			}
+			if actual := GetInt64(test.Parameter, mapper, test.Options...); actual != test.ExpectedFirst {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.ExpectedFirst, actual)
+			}
+		})
+	}
+}
+
+func TestUint(t *testing.T) {
+	mapper := map[string]interface{}{
+		"level":    float64(3),
+		"negative": -1,
+	}
+
+	tests := []struct {
+		Parameter      string
+		ExpectedFirst  uint
+		ExpectedSecond bool
+	}{
+		{Parameter: "level", ExpectedFirst: 3, ExpectedSecond: true},
+		{Parameter: "negative", ExpectedFirst: 0, ExpectedSecond: false},
+		{Parameter: "bananas", ExpectedFirst: 0, ExpectedSecond: false},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, result := Uint(test.Parameter, mapper)
+			if actual != test.ExpectedFirst || result != test.ExpectedSecond {
+				t.Errorf("[%s] expected param1: %v and param2: %v, but got param1: %v and param2: %v",
+					test.Parameter, test.ExpectedFirst, test.ExpectedSecond, actual, result)
+			}
+			if actual := GetUint(test.Parameter, mapper); actual != test.ExpectedFirst {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.ExpectedFirst, actual)
+			}
+		})
+	}
+}
+
+func TestUint64(t *testing.T) {
+	mapper := map[string]interface{}{
+		"max":      uint64(18446744073709551615),
+		"number":   json.Number("18446744073709551615"),
+		"overflow": json.Number("18446744073709551616"),
+	}
+
+	tests := []struct {
+		Parameter      string
+		ExpectedFirst  uint64
+		ExpectedSecond bool
+	}{
+		{Parameter: "max", ExpectedFirst: 18446744073709551615, ExpectedSecond: true},
+		{Parameter: "number", ExpectedFirst: 18446744073709551615, ExpectedSecond: true},
+		{Parameter: "overflow", ExpectedFirst: 0, ExpectedSecond: false},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, result := Uint64(test.Parameter, mapper)
+			if actual != test.ExpectedFirst || result != test.ExpectedSecond {
+				t.Errorf("[%s] expected param1: %v and param2: %v, but got param1: %v and param2: %v",
+					test.Parameter, test.ExpectedFirst, test.ExpectedSecond, actual, result)
+			}
+			if actual := GetUint64(test.Parameter, mapper); actual != test.ExpectedFirst {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.ExpectedFirst, actual)
+			}
+		})
+	}
+}
+
+func TestBool(t *testing.T) {
+	tests := []struct {
+		Parameter      string
+		ExpectedFirst  bool
+		ExpectedSecond bool
+	}{
+		{Parameter: "advert.status.active", ExpectedFirst: true, ExpectedSecond: true},
+		{Parameter: "advert.status.code", ExpectedFirst: false, ExpectedSecond: false},
+		{Parameter: "advert.bananas", ExpectedFirst: false, ExpectedSecond: false},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, result := Bool(test.Parameter, data)
+			if actual != test.ExpectedFirst || result != test.ExpectedSecond {
+				t.Errorf("[%s] expected param1: %v and param2: %v, but got param1: %v and param2: %v",
+					test.Parameter, test.ExpectedFirst, test.ExpectedSecond, actual, result)
+			}
+			if actual := GetBool(test.Parameter, data); actual != test.ExpectedFirst {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.ExpectedFirst, actual)
+			}
+		})
+	}
+}
+func ExampleMap_Bool() {
+	data, _ := NewFromJSON(`{"person": {"active": true}}`)
+
+	active, found := data.Bool("person.active")
+	fmt.Println(active, found)
+	// output: true true
+}
+
+func TestSlice(t *testing.T) {
+	tests := []struct {
+		Parameter      string
+		ExpectedFirst  []interface{}
+		ExpectedSecond bool
+	}{
+		{Parameter: "advert.tags", ExpectedFirst: []interface{}{"new", "sale"}, ExpectedSecond: true},
+		{Parameter: "advert.contact.phones", ExpectedFirst: []interface{}{"790123123", "790123546"}, ExpectedSecond: true},
+		{Parameter: "advert.title", ExpectedFirst: nil, ExpectedSecond: false},
+		{Parameter: "advert.bananas", ExpectedFirst: nil, ExpectedSecond: false},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, result := Slice(test.Parameter, data)
+			if !reflect.DeepEqual(actual, test.ExpectedFirst) || result != test.ExpectedSecond {
+				t.Errorf("[%s] expected param1: %v and param2: %v, but got param1: %v and param2: %v",
+					test.Parameter, test.ExpectedFirst, test.ExpectedSecond, actual, result)
+			}
+			if actual := GetSlice(test.Parameter, data); !reflect.DeepEqual(actual, test.ExpectedFirst) {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.ExpectedFirst, actual)
+			}
+		})
+	}
+}
+func ExampleMap_Slice() {
+	data, _ := NewFromJSON(`{"advert": {"tags": ["new", 1, true]}}`)
+
+	tags, found := data.Slice("advert.tags")
+	fmt.Println(tags, found)
+	// output: [new 1 true] true
+}
+
+func TestStringSlice(t *testing.T) {
+	tests := []struct {
+		Parameter      string
+		ExpectedFirst  []string
+		ExpectedSecond bool
+	}{
+		{Parameter: "advert.tags", ExpectedFirst: []string{"new", "sale"}, ExpectedSecond: true},
+		{Parameter: "advert.contact.phones", ExpectedFirst: []string{"790123123", "790123546"}, ExpectedSecond: true},
+		{Parameter: "advert.rates", ExpectedFirst: nil, ExpectedSecond: false},
+		{Parameter: "advert.bananas", ExpectedFirst: nil, ExpectedSecond: false},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, result := StringSlice(test.Parameter, data)
+			if !reflect.DeepEqual(actual, test.ExpectedFirst) || result != test.ExpectedSecond {
+				t.Errorf("[%s] expected param1: %v and param2: %v, but got param1: %v and param2: %v",
+					test.Parameter, test.ExpectedFirst, test.ExpectedSecond, actual, result)
+			}
+			if actual := GetStringSlice(test.Parameter, data); !reflect.DeepEqual(actual, test.ExpectedFirst) {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.ExpectedFirst, actual)
+			}
+		})
+	}
+}
+
+func TestIntSlice(t *testing.T) {
+	tests := []struct {
+		Parameter      string
+		ExpectedFirst  []int
+		ExpectedSecond bool
+	}{
+		{Parameter: "advert.rates", ExpectedFirst: []int{5, 4}, ExpectedSecond: true},
+		{Parameter: "numbers", ExpectedFirst: []int{1, 2}, ExpectedSecond: true},
+		{Parameter: "advert.tags", ExpectedFirst: nil, ExpectedSecond: false},
+		{Parameter: "advert.bananas", ExpectedFirst: nil, ExpectedSecond: false},
+	}
+
+	mapper := map[string]interface{}{"advert": data["advert"], "numbers": []int{1, 2}}
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, result := IntSlice(test.Parameter, mapper)
+			if !reflect.DeepEqual(actual, test.ExpectedFirst) || result != test.ExpectedSecond {
+				t.Errorf("[%s] expected param1: %v and param2: %v, but got param1: %v and param2: %v",
+					test.Parameter, test.ExpectedFirst, test.ExpectedSecond, actual, result)
+			}
+			if actual := GetIntSlice(test.Parameter, mapper); !reflect.DeepEqual(actual, test.ExpectedFirst) {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.ExpectedFirst, actual)
+			}
+		})
+	}
+}
+
+func TestMapSlice(t *testing.T) {
+	tests := []struct {
+		Parameter      string
+		ExpectedFirst  []Map
+		ExpectedSecond bool
+	}{
+		{
+			Parameter: "advert.images",
+			ExpectedFirst: []Map{
+				{"url": "www.loremipsum.com/1.jpg", "order": 1, "created": "1987-01-29T19:00:00Z"},
+				{"url": "www.loremipsum.com/2.jpg", "order": 2},
+			},
+			ExpectedSecond: true,
+		},
+		{Parameter: "advert.tags", ExpectedFirst: nil, ExpectedSecond: false},
+		{Parameter: "advert.bananas", ExpectedFirst: nil, ExpectedSecond: false},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, result := MapSlice(test.Parameter, data)
+			if !reflect.DeepEqual(actual, test.ExpectedFirst) || result != test.ExpectedSecond {
+				t.Errorf("[%s] expected param1: %v and param2: %v, but got param1: %v and param2: %v",
+					test.Parameter, test.ExpectedFirst, test.ExpectedSecond, actual, result)
+			}
+			if actual := GetMapSlice(test.Parameter, data); !reflect.DeepEqual(actual, test.ExpectedFirst) {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.ExpectedFirst, actual)
+			}
+		})
+	}
+}
+func ExampleMap_MapSlice() {
+	data, _ := NewFromJSON(`{"images": [{"url": "www.loremipsum.com/1.jpg"}, {"url": "www.loremipsum.com/2.jpg"}]}`)
+
+	images, _ := data.MapSlice("images")
+	for _, image := range images {
+		fmt.Println(image.GetString("url"))
+	}
+	// output:
+	// www.loremipsum.com/1.jpg
+	// www.loremipsum.com/2.jpg
+}
+
 func randomData() map[string]interface{} {
 	return map[string]interface{}{
 		"advert": map[string]interface{}{
@@ -0,0 +1,82 @@
+package nested
+
+import "time"
+
+// Option changes how the getters read the values from Map and how Merge joins the maps.
+type Option func(*options)
+
+// options is the configuration changed by Option.
+type options struct {
+	lenient  bool
+	layout   string
+	tag      string
+	conflict ConflictStrategy
+	mismatch ConflictStrategy
+	slices   SliceStrategy
+	keyField string
+
+	ignore       []string
+	numericEqual bool
+
+	loose bool
+
+	layouts   []string
+	detect    bool
+	epoch     bool
+	epochUnit time.Duration
+	location  *time.Location
+}
+
+// tagName returns the tag of struct fields used by Decode.
+func (o options) tagName() string {
+	if o.tag == "" {
+		return "json"
+	}
+	return o.tag
+}
+
+// Lenient makes the numeric getters accept numeric strings like "12" or "19.9",
+// by default only numeric types and json.Number are converted.
+func Lenient() Option {
+	return func(o *options) {
+		o.lenient = true
+	}
+}
+
+// Layout changes the layout used to parse strings to time.Time, by default it is time.RFC3339.
+func Layout(layout string) Option {
+	return func(o *options) {
+		o.layout = layout
+	}
+}
+
+// TagName changes the tag of struct fields used by Decode, by default it is json.
+func TagName(tag string) Option {
+	return func(o *options) {
+		o.tag = tag
+	}
+}
+
+// Strict changes how the positions are resolved, by default it is true and the value is found only when
+// every segment of the position exists, so person.name.first is not found when person.name is a string.
+// Strict(false) returns the first value that is not a map or a slice ignoring the remaining segments,
+// like the old versions did.
+func Strict(strict bool) Option {
+	return func(o *options) {
+		o.loose = !strict
+	}
+}
+
+// newOptions returns the options with all opts applied,
+// without opts it doesn't allocate memory.
+func newOptions(opts []Option) options {
+	if len(opts) == 0 {
+		return options{}
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,139 @@
+package nested
+
+import (
+	"time"
+)
+
+// StringOr returns the string value from position that you passed by argument,
+// if it doesn't find the field or the value is not a string it returns def.
+func (m Map) StringOr(position, def string) string {
+	return GetOr(m, position, def)
+}
+
+// IntOr returns the int value from position converted like Int,
+// if it doesn't find the field or the value cannot be converted it returns def.
+func (m Map) IntOr(position string, def int, opts ...Option) int {
+	return GetOr(m, position, def, opts...)
+}
+
+// Int64Or returns the int64 value from position converted like Int64,
+// if it doesn't find the field or the value cannot be converted it returns def.
+func (m Map) Int64Or(position string, def int64, opts ...Option) int64 {
+	return GetOr(m, position, def, opts...)
+}
+
+// UintOr returns the uint value from position converted like Uint,
+// if it doesn't find the field or the value cannot be converted it returns def.
+func (m Map) UintOr(position string, def uint, opts ...Option) uint {
+	return GetOr(m, position, def, opts...)
+}
+
+// Uint64Or returns the uint64 value from position converted like Uint64,
+// if it doesn't find the field or the value cannot be converted it returns def.
+func (m Map) Uint64Or(position string, def uint64, opts ...Option) uint64 {
+	return GetOr(m, position, def, opts...)
+}
+
+// Float64Or returns the float64 value from position converted like Float64,
+// if it doesn't find the field or the value cannot be converted it returns def.
+func (m Map) Float64Or(position string, def float64, opts ...Option) float64 {
+	return GetOr(m, position, def, opts...)
+}
+
+// BoolOr returns the bool value from position that you passed by argument,
+// if it doesn't find the field or the value is not a bool it returns def.
+func (m Map) BoolOr(position string, def bool) bool {
+	return GetOr(m, position, def)
+}
+
+// TimeOr returns the time.Time value from position parsed with layout like Time,
+// if it doesn't find the field or the value cannot be parsed it returns def.
+func (m Map) TimeOr(position, layout string, def time.Time) time.Time {
+	return GetOr(m, position, def, Layout(layout))
+}
+
+// StringSliceOr returns the []string value from position that you passed by argument,
+// if it doesn't find the field or some element is not a string it returns def.
+func (m Map) StringSliceOr(position string, def []string) []string {
+	return GetOr(m, position, def)
+}
+
+// Coalesce returns the value of the first position that is found and it is not nil,
+// like when the same field has a legacy and a new name: Coalesce("user.name", "profile.display_name").
+// if no position is found it returns nil and false.
+func (m Map) Coalesce(positions ...string) (interface{}, bool) {
+	for _, position := range positions {
+		if value, ok := m.Interface(position); ok && value != nil {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// GetFirst returns the value of the first position that is found and can be converted to the type T like Get,
+// if no position can be used it returns the zero value of T and false.
+func GetFirst[T any](m Map, positions ...string) (T, bool) {
+	for _, position := range positions {
+		valueTmp, ok := m.Interface(position)
+		if !ok || valueTmp == nil {
+			continue
+		}
+
+		if value, err := convertTo[T](position, valueTmp, nil); err == nil {
+			return value, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// StringOr is helper for function StringOr from Map.
+func StringOr(position string, mapper map[string]interface{}, def string) string {
+	return New(mapper).StringOr(position, def)
+}
+
+// IntOr is helper for function IntOr from Map.
+func IntOr(position string, mapper map[string]interface{}, def int, opts ...Option) int {
+	return New(mapper).IntOr(position, def, opts...)
+}
+
+// Int64Or is helper for function Int64Or from Map.
+func Int64Or(position string, mapper map[string]interface{}, def int64, opts ...Option) int64 {
+	return New(mapper).Int64Or(position, def, opts...)
+}
+
+// UintOr is helper for function UintOr from Map.
+func UintOr(position string, mapper map[string]interface{}, def uint, opts ...Option) uint {
+	return New(mapper).UintOr(position, def, opts...)
+}
+
+// Uint64Or is helper for function Uint64Or from Map.
+func Uint64Or(position string, mapper map[string]interface{}, def uint64, opts ...Option) uint64 {
+	return New(mapper).Uint64Or(position, def, opts...)
+}
+
+// Float64Or is helper for function Float64Or from Map.
+func Float64Or(position string, mapper map[string]interface{}, def float64, opts ...Option) float64 {
+	return New(mapper).Float64Or(position, def, opts...)
+}
+
+// BoolOr is helper for function BoolOr from Map.
+func BoolOr(position string, mapper map[string]interface{}, def bool) bool {
+	return New(mapper).BoolOr(position, def)
+}
+
+// TimeOr is helper for function TimeOr from Map.
+func TimeOr(position string, mapper map[string]interface{}, layout string, def time.Time) time.Time {
+	return New(mapper).TimeOr(position, layout, def)
+}
+
+// StringSliceOr is helper for function StringSliceOr from Map.
+func StringSliceOr(position string, mapper map[string]interface{}, def []string) []string {
+	return New(mapper).StringSliceOr(position, def)
+}
+
+// Coalesce is helper for function Coalesce from Map.
+func Coalesce(mapper map[string]interface{}, positions ...string) (interface{}, bool) {
+	return New(mapper).Coalesce(positions...)
+}
@@ -0,0 +1,104 @@
+package nested
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOr(t *testing.T) {
+	m := New(map[string]interface{}{
+		"name":    "daniel",
+		"level":   0,
+		"price":   "19.9",
+		"active":  false,
+		"created": "2020-02-13",
+		"tags":    []interface{}{"a", 1},
+		"empty":   nil,
+	})
+	created := time.Date(2020, 2, 13, 0, 0, 0, 0, time.UTC)
+	def := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Actual   interface{}
+		Expected interface{}
+	}{
+		{Actual: m.StringOr("name", "anonymous"), Expected: "daniel"},
+		{Actual: m.StringOr("nickname", "anonymous"), Expected: "anonymous"},
+		{Actual: m.StringOr("level", "anonymous"), Expected: "anonymous"},
+		{Actual: m.StringOr("empty", "anonymous"), Expected: "anonymous"},
+		{Actual: m.IntOr("level", 10), Expected: 0},
+		{Actual: m.IntOr("missing", 10), Expected: 10},
+		{Actual: m.Int64Or("name", 10), Expected: int64(10)},
+		{Actual: m.UintOr("level", 10), Expected: uint(0)},
+		{Actual: m.Uint64Or("missing", 10), Expected: uint64(10)},
+		{Actual: m.Float64Or("price", 1.5), Expected: 1.5},
+		{Actual: m.Float64Or("price", 1.5, Lenient()), Expected: 19.9},
+		{Actual: m.BoolOr("active", true), Expected: false},
+		{Actual: m.BoolOr("missing", true), Expected: true},
+		{Actual: m.TimeOr("created", "2006-01-02", def), Expected: created},
+		{Actual: m.TimeOr("created", time.RFC3339, def), Expected: def},
+		{Actual: m.StringSliceOr("tags", []string{"none"}), Expected: []string{"none"}},
+		{Actual: StringOr("name", m, "anonymous"), Expected: "daniel"},
+		{Actual: IntOr("missing", m, 3), Expected: 3},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			if !reflect.DeepEqual(test.Expected, test.Actual) {
+				t.Errorf("expected %v, but got %v", test.Expected, test.Actual)
+			}
+		})
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	data := map[string]interface{}{
+		"user":    map[string]interface{}{"name": nil, "age": "old"},
+		"profile": map[string]interface{}{"display_name": "daniel", "age": 33},
+	}
+
+	tests := []struct {
+		Parameter     []string
+		ExpectedFirst interface{}
+		Found         bool
+	}{
+		{Parameter: []string{"user.name", "profile.display_name"}, ExpectedFirst: "daniel", Found: true},
+		{Parameter: []string{"user.age", "profile.age"}, ExpectedFirst: "old", Found: true},
+		{Parameter: []string{"user.missing", "user.name"}, ExpectedFirst: nil, Found: false},
+		{Parameter: nil, ExpectedFirst: nil, Found: false},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, found := Coalesce(data, test.Parameter...)
+			if found != test.Found {
+				t.Errorf("%v expected found %v, but got %v", test.Parameter, test.Found, found)
+			}
+			if actual != test.ExpectedFirst {
+				t.Errorf("%v expected %v, but got %v", test.Parameter, test.ExpectedFirst, actual)
+			}
+		})
+	}
+
+	if age, ok := GetFirst[int](New(data), "user.age", "profile.age"); !ok || age != 33 {
+		t.Errorf("expected 33 from profile.age, but got %v", age)
+	}
+	if _, ok := GetFirst[int](New(data), "user.name", "user.age"); ok {
+		t.Error("expected false when no position is an int")
+	}
+}
+
+func ExampleMap_Coalesce() {
+	legacy, _ := NewFromJSON(`{"user": {"name": "daniel"}}`)
+	current, _ := NewFromJSON(`{"profile": {"display_name": "rod"}}`)
+
+	for _, m := range []Map{legacy, current} {
+		name, _ := GetFirst[string](m, "user.name", "profile.display_name")
+		fmt.Println(name, m.IntOr("profile.level", 1))
+	}
+	// output:
+	// daniel 1
+	// rod 1
+}
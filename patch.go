@@ -0,0 +1,352 @@
+package nested
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrTestFailed when the value of a test operation of JSON Patch is not the value in the path.
+var ErrTestFailed = errors.New("the test operation failed")
+
+// Operation is an operation of JSON Patch (RFC 6902), Op is add, remove, replace, move, copy or test,
+// Path and From are JSON Pointers like /advert/contact/phones/0.
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON writes the operation with value only for add, replace and test, including null values.
+func (op Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+	if op.Op != "add" && op.Op != "replace" && op.Op != "test" {
+		return json.Marshal(operation(op))
+	}
+
+	return json.Marshal(struct {
+		operation
+		Value interface{} `json:"value"`
+	}{operation: operation(op), Value: op.Value})
+}
+
+// ApplyPatch applies the operations of JSON Patch (RFC 6902) in order,
+// if some operation fails the Map is not changed and it returns *PatchError with the operation.
+// It returns ErrInvalidInputType if the Map is nil.
+func (m Map) ApplyPatch(ops []Operation) error {
+	if m == nil {
+		return fmt.Errorf("%w: ApplyPatch needs a non-nil Map", ErrInvalidInputType)
+	}
+
+	var doc interface{} = deepCopy(map[string]interface{}(m))
+
+	for i, op := range ops {
+		var err error
+		if doc, err = applyOperation(doc, op); err != nil {
+			return &PatchError{Index: i, Op: op, Err: err}
+		}
+	}
+
+	out, ok := doc.(map[string]interface{})
+	if !ok {
+		out = make(map[string]interface{})
+		for _, item := range children(doc) {
+			out[item.Path] = item.Value
+		}
+	}
+
+	for key := range m {
+		delete(m, key)
+	}
+	for key, value := range out {
+		m[key] = value
+	}
+	return nil
+}
+
+// applyOperation applies op to doc and returns the document changed.
+func applyOperation(doc interface{}, op Operation) (interface{}, error) {
+	switch op.Op {
+	case "add":
+		return patchAdd(doc, op.Path, deepCopy(op.Value))
+	case "remove":
+		doc, _, err := patchRemove(doc, op.Path)
+		return doc, err
+	case "replace":
+		if _, err := patchGet(doc, op.Path); err != nil {
+			return doc, err
+		}
+		if op.Path == "" {
+			return patchRoot(op.Value)
+		}
+		return patchUpdate(doc, op.Path, func(container interface{}, pos []segment) (interface{}, error) {
+			return container, assign(container, pos, deepCopy(op.Value))
+		})
+	case "move":
+		if op.From == op.Path {
+			_, err := patchGet(doc, op.From)
+			return doc, err
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return doc, fmt.Errorf("%w: cannot move %q to its child %q", ErrInvalidPosition, op.From, op.Path)
+		}
+
+		doc, value, err := patchRemove(doc, op.From)
+		if err != nil {
+			return doc, err
+		}
+		return patchAdd(doc, op.Path, value)
+	case "copy":
+		value, err := patchGet(doc, op.From)
+		if err != nil {
+			return doc, err
+		}
+		return patchAdd(doc, op.Path, deepCopy(value))
+	case "test":
+		value, err := patchGet(doc, op.Path)
+		if err != nil {
+			return doc, err
+		}
+		if !jsonEqual(value, op.Value) {
+			return doc, ErrTestFailed
+		}
+		return doc, nil
+	}
+
+	return doc, fmt.Errorf("unknown operation %q", op.Op)
+}
+
+// patchSegments returns the segments of JSON Pointer path, the empty path is the whole document.
+func patchSegments(path string) ([]segment, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !isPointer(path) {
+		return nil, syntaxError(path, 0, "json pointer must start with /")
+	}
+	return splitPointer(path)
+}
+
+// patchGet returns the value in path of doc.
+func patchGet(doc interface{}, path string) (interface{}, error) {
+	pos, err := patchSegments(path)
+	if err != nil || len(pos) == 0 {
+		return doc, err
+	}
+
+	node := doc
+	for _, seg := range pos {
+		v, ok := child(node, seg)
+		if !ok {
+			return nil, &PathNotFoundError{Path: path, MissingSegment: seg.key}
+		}
+		node = v
+	}
+	return node, nil
+}
+
+// patchRoot returns value as the whole document, it must be a map.
+func patchRoot(value interface{}) (interface{}, error) {
+	if valueKind(value) != "map" {
+		return nil, mismatch("", "map", value, nil)
+	}
+	return deepCopy(value), nil
+}
+
+// patchUpdate calls fn with the container of the last segment of path and stores the returned container in its parent.
+func patchUpdate(doc interface{}, path string, fn func(container interface{}, pos []segment) (interface{}, error)) (interface{}, error) {
+	pos, err := patchSegments(path)
+	if err != nil {
+		return doc, err
+	}
+
+	return updateIn(doc, pos, pos, path, fn)
+}
+
+// updateIn goes through rest starting from node until the container of the last segment, all is used in the errors.
+func updateIn(node interface{}, all, rest []segment, path string, fn func(interface{}, []segment) (interface{}, error)) (interface{}, error) {
+	if len(rest) == 1 {
+		return fn(node, all)
+	}
+
+	next, ok := child(node, rest[0])
+	if !ok {
+		return node, &PathNotFoundError{Path: path, MissingSegment: rest[0].key}
+	}
+
+	value, err := updateIn(next, all, rest[1:], path, fn)
+	if err != nil {
+		return node, err
+	}
+
+	return node, assign(node, all[:len(all)-len(rest)+1], value)
+}
+
+// patchAdd adds value in path of doc, maps get the key and slices get the element inserted in the index or
+// appended with the index -.
+func patchAdd(doc interface{}, path string, value interface{}) (interface{}, error) {
+	if path == "" {
+		return patchRoot(value)
+	}
+
+	return patchUpdate(doc, path, func(container interface{}, pos []segment) (interface{}, error) {
+		seg := pos[len(pos)-1]
+
+		switch valueKind(container) {
+		case "map":
+			return container, assign(container, pos, value)
+		case "slice":
+			items, _ := toSlice(container)
+
+			index := len(items)
+			if seg.key != "-" {
+				if !seg.isIndex || seg.index > len(items) {
+					return container, &IndexError{Path: path, Index: seg.index, Length: len(items)}
+				}
+				index = seg.index
+			}
+
+			out := make([]interface{}, 0, len(items)+1)
+			out = append(out, items[:index]...)
+			out = append(out, value)
+			return append(out, items[index:]...), nil
+		}
+
+		return container, &NotContainerError{Path: path, Value: container}
+	})
+}
+
+// patchRemove removes path from doc and returns the value removed.
+func patchRemove(doc interface{}, path string) (interface{}, interface{}, error) {
+	value, err := patchGet(doc, path)
+	if err != nil {
+		return doc, nil, err
+	}
+	if path == "" {
+		return doc, nil, fmt.Errorf("%w: the whole document cannot be removed", ErrInvalidPosition)
+	}
+
+	doc, err = patchUpdate(doc, path, func(container interface{}, pos []segment) (interface{}, error) {
+		out, _ := removeChild(container, pos[len(pos)-1])
+		return out, nil
+	})
+	return doc, value, err
+}
+
+// jsonEqual returns true if a and b are equal like JSON values, numbers are compared by value.
+func jsonEqual(a, b interface{}) bool {
+	return len(Diff(Map{"": a}, Map{"": b}, NumericEqual())) == 0
+}
+
+// CreatePatch returns the operations of JSON Patch (RFC 6902) that change a to b, the maps are compared recursively
+// and the slices keep their longest common subsequence, so inserting or removing one element is one operation.
+func CreatePatch(a, b Map) []Operation {
+	var ops []Operation
+	createPatch(&ops, "", map[string]interface{}(a), map[string]interface{}(b))
+	return ops
+}
+
+// createPatch appends to ops the operations that change a to b in pointer.
+func createPatch(ops *[]Operation, pointer string, a, b interface{}) {
+	kindA, kindB := valueKind(a), valueKind(b)
+	switch {
+	case kindA == "map" && kindB == "map":
+		itemsA, itemsB := children(a), children(b)
+
+		valuesB := make(map[string]interface{}, len(itemsB))
+		for _, item := range itemsB {
+			valuesB[item.Path] = item.Value
+		}
+
+		keysA := make(map[string]bool, len(itemsA))
+		for _, item := range itemsA {
+			keysA[item.Path] = true
+
+			childPointer := pointer + "/" + pointerEscaper.Replace(item.Path)
+			if valueB, ok := valuesB[item.Path]; ok {
+				createPatch(ops, childPointer, item.Value, valueB)
+				continue
+			}
+			*ops = append(*ops, Operation{Op: "remove", Path: childPointer})
+		}
+
+		added := make([]string, 0, len(itemsB))
+		for _, item := range itemsB {
+			if !keysA[item.Path] {
+				added = append(added, item.Path)
+			}
+		}
+		sort.Strings(added)
+
+		for _, key := range added {
+			*ops = append(*ops, Operation{Op: "add", Path: pointer + "/" + pointerEscaper.Replace(key), Value: deepCopy(valuesB[key])})
+		}
+	case kindA == "slice" && kindB == "slice":
+		itemsA, _ := toSlice(a)
+		itemsB, _ := toSlice(b)
+		createSlicePatch(ops, pointer, itemsA, itemsB)
+	case !jsonEqual(a, b):
+		*ops = append(*ops, Operation{Op: "replace", Path: pointer, Value: deepCopy(b)})
+	}
+}
+
+// createSlicePatch appends to ops the operations that change the slice a to b in pointer, the elements of
+// the longest common subsequence are kept, so inserting or removing one element is one operation.
+// An element that is neither kept, added or removed is changed recursively by createPatch.
+func createSlicePatch(ops *[]Operation, pointer string, a, b []interface{}) {
+	// common[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case jsonEqual(a[i], b[j]):
+				common[i][j] = common[i+1][j+1] + 1
+			case common[i+1][j] >= common[i][j+1]:
+				common[i][j] = common[i+1][j]
+			default:
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	// index is the position in the slice after the operations already appended, the elements removed
+	// one after another are removed from the last so each operation has the index of the element.
+	i, j, index, removed := 0, 0, 0, 0
+	flush := func() {
+		for ; removed > 0; removed-- {
+			*ops = append(*ops, Operation{Op: "remove", Path: pointer + "/" + strconv.Itoa(index+removed-1)})
+		}
+	}
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && jsonEqual(a[i], b[j]):
+			flush()
+			i, j, index = i+1, j+1, index+1
+		case i < len(a) && j < len(b) && common[i][j] == common[i+1][j+1]:
+			flush()
+			createPatch(ops, pointer+"/"+strconv.Itoa(index), a[i], b[j])
+			i, j, index = i+1, j+1, index+1
+		case j < len(b) && (i == len(a) || common[i][j+1] >= common[i+1][j]):
+			flush()
+			*ops = append(*ops, Operation{Op: "add", Path: pointer + "/" + strconv.Itoa(index), Value: deepCopy(b[j])})
+			j, index = j+1, index+1
+		default:
+			removed++
+			i++
+		}
+	}
+	flush()
+}
+
+// ApplyPatch is helper for function ApplyPatch from Map.
+func ApplyPatch(mapper map[string]interface{}, ops []Operation) error {
+	return New(mapper).ApplyPatch(ops)
+}
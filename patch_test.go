@@ -0,0 +1,303 @@
+package nested
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func patchDocument() Map {
+	return Map{
+		"name": "api",
+		"server": map[string]interface{}{
+			"host": "localhost",
+			"port": 8080,
+		},
+		"hosts":  []interface{}{"a", "b", "c"},
+		"labels": map[string]interface{}{"k8s.io/name": "api"},
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	tests := []struct {
+		Ops      []Operation
+		Position string
+		Expected interface{}
+	}{
+		{
+			Ops:      []Operation{{Op: "add", Path: "/server/tls", Value: true}},
+			Position: "server.tls",
+			Expected: true,
+		},
+		{
+			Ops:      []Operation{{Op: "add", Path: "/hosts/1", Value: "x"}},
+			Position: "hosts",
+			Expected: []interface{}{"a", "x", "b", "c"},
+		},
+		{
+			Ops:      []Operation{{Op: "add", Path: "/hosts/-", Value: "d"}},
+			Position: "hosts",
+			Expected: []interface{}{"a", "b", "c", "d"},
+		},
+		{
+			Ops:      []Operation{{Op: "add", Path: "/hosts/3", Value: "d"}},
+			Position: "hosts",
+			Expected: []interface{}{"a", "b", "c", "d"},
+		},
+		{
+			Ops:      []Operation{{Op: "remove", Path: "/hosts/0"}},
+			Position: "hosts",
+			Expected: []interface{}{"b", "c"},
+		},
+		{
+			Ops:      []Operation{{Op: "remove", Path: "/server/port"}},
+			Position: "server",
+			Expected: map[string]interface{}{"host": "localhost"},
+		},
+		{
+			Ops:      []Operation{{Op: "replace", Path: "/server/port", Value: 9090}},
+			Position: "server.port",
+			Expected: 9090,
+		},
+		{
+			Ops:      []Operation{{Op: "replace", Path: "/labels/k8s.io~1name", Value: "web"}},
+			Position: `labels.k8s\.io/name`,
+			Expected: "web",
+		},
+		{
+			Ops:      []Operation{{Op: "move", From: "/server/host", Path: "/host"}},
+			Position: "server",
+			Expected: map[string]interface{}{"port": 8080},
+		},
+		{
+			Ops:      []Operation{{Op: "move", From: "/hosts/0", Path: "/hosts/-"}},
+			Position: "hosts",
+			Expected: []interface{}{"b", "c", "a"},
+		},
+		{
+			Ops:      []Operation{{Op: "copy", From: "/server", Path: "/backup"}, {Op: "remove", Path: "/server/host"}},
+			Position: "backup",
+			Expected: map[string]interface{}{"host": "localhost", "port": 8080},
+		},
+		{
+			Ops:      []Operation{{Op: "test", Path: "/server/port", Value: 8080.0}, {Op: "add", Path: "/tested", Value: true}},
+			Position: "tested",
+			Expected: true,
+		},
+		{
+			Ops:      []Operation{{Op: "test", Path: "/hosts", Value: []interface{}{"a", "b", "c"}}},
+			Position: "name",
+			Expected: "api",
+		},
+		{
+			Ops:      []Operation{{Op: "replace", Path: "", Value: map[string]interface{}{"name": "web"}}},
+			Position: "name",
+			Expected: "web",
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			m := patchDocument()
+
+			if err := m.ApplyPatch(test.Ops); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if actual := m.GetInterface(test.Position); !reflect.DeepEqual(test.Expected, actual) {
+				t.Errorf("[%s] expected %v, but got %v", test.Position, test.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestApplyPatchError(t *testing.T) {
+	tests := []struct {
+		Ops      []Operation
+		Index    int
+		Expected error
+		Target   interface{}
+	}{
+		{Ops: []Operation{{Op: "remove", Path: "/missing"}}, Target: new(*PathNotFoundError)},
+		{Ops: []Operation{{Op: "replace", Path: "/server/missing", Value: 1}}, Target: new(*PathNotFoundError)},
+		{Ops: []Operation{{Op: "add", Path: "/missing/key", Value: 1}}, Target: new(*PathNotFoundError)},
+		{Ops: []Operation{{Op: "add", Path: "/hosts/4", Value: "x"}}, Target: new(*IndexError)},
+		{Ops: []Operation{{Op: "add", Path: "/name/key", Value: "x"}}, Target: new(*NotContainerError)},
+		{Ops: []Operation{{Op: "add", Path: "name", Value: "x"}}, Expected: ErrInvalidPosition},
+		{Ops: []Operation{{Op: "move", From: "/server", Path: "/server/old"}}, Expected: ErrInvalidPosition},
+		{Ops: []Operation{{Op: "add", Path: "/x", Value: 1}, {Op: "test", Path: "/name", Value: "web"}}, Index: 1, Expected: ErrTestFailed},
+		{Ops: []Operation{{Op: "replace", Path: "", Value: "web"}}, Target: new(*TypeMismatchError)},
+		{Ops: []Operation{{Op: "rename", Path: "/name"}}},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			m := patchDocument()
+
+			err := m.ApplyPatch(test.Ops)
+
+			var patchErr *PatchError
+			if !errors.As(err, &patchErr) || patchErr.Index != test.Index {
+				t.Fatalf("expected *PatchError for operation %d, but got %v", test.Index, err)
+			}
+			if test.Expected != nil && !errors.Is(err, test.Expected) {
+				t.Errorf("expected %v, but got %v", test.Expected, err)
+			}
+			if test.Target != nil && !errors.As(err, test.Target) {
+				t.Errorf("expected %T, but got %v", test.Target, err)
+			}
+
+			if !reflect.DeepEqual(patchDocument(), m) {
+				t.Errorf("expected map not changed, but got %v", m)
+			}
+		})
+	}
+
+	var nilMap Map
+	if err := nilMap.ApplyPatch([]Operation{{Op: "add", Path: "/name", Value: "web"}}); !errors.Is(err, ErrInvalidInputType) {
+		t.Errorf("expected ErrInvalidInputType for nil Map, but got %v", err)
+	}
+}
+
+func TestCreatePatch(t *testing.T) {
+	tests := []struct {
+		A, B     Map
+		Expected []Operation
+	}{
+		{
+			A:        patchDocument(),
+			B:        patchDocument(),
+			Expected: nil,
+		},
+		{
+			A: Map{"name": "api", "port": 8080, "old": true},
+			B: Map{"name": "web", "port": 8080.0, "new": true},
+			Expected: []Operation{
+				{Op: "replace", Path: "/name", Value: "web"},
+				{Op: "remove", Path: "/old"},
+				{Op: "add", Path: "/new", Value: true},
+			},
+		},
+		{
+			A: Map{"hosts": []interface{}{"a", "b", "c", "d"}},
+			B: Map{"hosts": []interface{}{"a", "x"}},
+			Expected: []Operation{
+				{Op: "replace", Path: "/hosts/1", Value: "x"},
+				{Op: "remove", Path: "/hosts/3"},
+				{Op: "remove", Path: "/hosts/2"},
+			},
+		},
+		{
+			A: Map{"hosts": []interface{}{"a"}, "labels": map[string]interface{}{"a/b": 1}},
+			B: Map{"hosts": []interface{}{"a", "b"}, "labels": map[string]interface{}{"a/b": "1"}},
+			Expected: []Operation{
+				{Op: "add", Path: "/hosts/1", Value: "b"},
+				{Op: "replace", Path: "/labels/a~1b", Value: "1"},
+			},
+		},
+		{
+			A:        Map{"hosts": []interface{}{"b", "c", "d", "e"}},
+			B:        Map{"hosts": []interface{}{"a", "b", "c", "d", "e"}},
+			Expected: []Operation{{Op: "add", Path: "/hosts/0", Value: "a"}},
+		},
+		{
+			A: Map{"hosts": []interface{}{"a", "b", "c", "d", "e"}},
+			B: Map{"hosts": []interface{}{"c", "x", "e"}},
+			Expected: []Operation{
+				{Op: "remove", Path: "/hosts/1"},
+				{Op: "remove", Path: "/hosts/0"},
+				{Op: "replace", Path: "/hosts/1", Value: "x"},
+			},
+		},
+		{
+			A:        Map{"users": []interface{}{map[string]interface{}{"id": 1, "name": "a"}, map[string]interface{}{"id": 2}}},
+			B:        Map{"users": []interface{}{map[string]interface{}{"id": 1, "name": "b"}, map[string]interface{}{"id": 2}}},
+			Expected: []Operation{{Op: "replace", Path: "/users/0/name", Value: "b"}},
+		},
+		{
+			A:        Map{"server": map[string]interface{}{"port": 1}},
+			B:        Map{"server": []interface{}{1}},
+			Expected: []Operation{{Op: "replace", Path: "/server", Value: []interface{}{1}}},
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual := CreatePatch(test.A, test.B)
+			if !reflect.DeepEqual(test.Expected, actual) {
+				t.Fatalf("expected %v, but got %v", test.Expected, actual)
+			}
+
+			if err := test.A.ApplyPatch(actual); err != nil {
+				t.Fatal(err)
+			}
+			if changes := Diff(test.A, test.B, NumericEqual()); len(changes) != 0 {
+				t.Errorf("expected no changes after the patch, but got %v", changes)
+			}
+		})
+	}
+
+	items := make([]interface{}, 100)
+	for i := range items {
+		items[i] = i
+	}
+	inserted := append([]interface{}{-1}, items...)
+	if ops := CreatePatch(Map{"items": items}, Map{"items": inserted}); len(ops) != 1 {
+		t.Errorf("expected 1 operation for the insertion in front, but got %d: %v", len(ops), ops)
+	}
+}
+
+func TestOperationJSON(t *testing.T) {
+	ops := []Operation{
+		{Op: "add", Path: "/a", Value: nil},
+		{Op: "remove", Path: "/a"},
+		{Op: "move", From: "/a", Path: "/b"},
+	}
+
+	b, err := json.Marshal(ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `[{"op":"add","path":"/a","value":null},{"op":"remove","path":"/a"},{"op":"move","path":"/b","from":"/a"}]`
+	if string(b) != expected {
+		t.Errorf("expected %s, but got %s", expected, b)
+	}
+
+	var decoded []Operation
+	if err := json.Unmarshal(b, &decoded); err != nil || !reflect.DeepEqual(ops, decoded) {
+		t.Errorf("expected %v, but got %v (%v)", ops, decoded, err)
+	}
+}
+
+func ExampleMap_ApplyPatch() {
+	m, _ := NewFromJSON(`{"server": {"host": "localhost"}, "hosts": ["a", "b"]}`)
+
+	var ops []Operation
+	_ = json.Unmarshal([]byte(`[
+		{"op": "test", "path": "/server/host", "value": "localhost"},
+		{"op": "replace", "path": "/server/host", "value": "example.com"},
+		{"op": "add", "path": "/hosts/-", "value": "c"}
+	]`), &ops)
+
+	if err := m.ApplyPatch(ops); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(m.GetString("server.host"), m.GetStringSlice("hosts"))
+	// output:
+	// example.com [a b c]
+}
+
+func ExampleCreatePatch() {
+	a, _ := NewFromJSON(`{"name": "api", "hosts": ["a", "b", "c"]}`)
+	b, _ := NewFromJSON(`{"name": "web", "hosts": ["a"]}`)
+
+	patch, _ := json.Marshal(CreatePatch(a, b))
+	fmt.Println(string(patch))
+	// output:
+	// [{"op":"remove","path":"/hosts/2"},{"op":"remove","path":"/hosts/1"},{"op":"replace","path":"/name","value":"web"}]
+}
@@ -0,0 +1,427 @@
+package nested
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// segment is one step of a position, it is a key for maps or an index for slices.
+// wildcard (*) and recursive (**) segments are used only by All, the other functions use them as keys.
+type segment struct {
+	key       string
+	index     int
+	isIndex   bool
+	wildcard  bool
+	recursive bool
+}
+
+// Path is a position parsed by ParsePath, it can be used many times without parsing it again.
+type Path struct {
+	position string
+	segments []segment
+}
+
+// ParsePath parses the position separately by . (dot) or the JSON Pointer that starts with / (slash),
+// it returns *SyntaxError with the column where the position is not valid.
+//
+// A key with . (dot), [ or \ can be escaped with \ like a\.b.c, or it can be quoted
+// inside of brackets like a["b.c"].d or a['b.c'].d.
+func ParsePath(position string) (Path, error) {
+	pos, err := parsePosition(position)
+	if err != nil {
+		return Path{}, err
+	}
+	return Path{position: position, segments: pos}, nil
+}
+
+// Position returns the position that was parsed.
+func (p Path) Position() string {
+	return p.position
+}
+
+// Keys returns the keys and indexes of the path without escapes or quotes.
+func (p Path) Keys() []string {
+	keys := make([]string, len(p.segments))
+	for i, seg := range p.segments {
+		keys[i] = seg.key
+	}
+	return keys
+}
+
+// splitPosition breaks a position into segments like parsePosition,
+// it returns nil if the position is not valid.
+func splitPosition(position string) []segment {
+	pos, err := parsePosition(position)
+	if err != nil {
+		return nil
+	}
+	return pos
+}
+
+// parsePosition breaks a position separately by . (dot) into segments,
+// numeric segments (phones.0) and bracket segments (phones[0], phones[-1]) are indexes for slices,
+// * and [*] are wildcards and ** is a recursive segment. Keys can have escapes (a\.b) or
+// be quoted in brackets (a["b.c"]), these keys are never indexes or wildcards.
+// Positions that start with / (slash) are JSON Pointers and they are split by splitPointer.
+func parsePosition(position string) ([]segment, error) {
+	if isPointer(position) {
+		return splitPointer(position)
+	}
+
+	if !strings.ContainsAny(position, "[\\") {
+		return splitPlain(position), nil
+	}
+
+	// one segment for each . (dot) and [ is enough for most positions, it avoids growing the slice.
+	segments := make([]segment, 0, strings.Count(position, ".")+strings.Count(position, "[")+1)
+	for i := 0; ; {
+		if i == len(position) || position[i] != '[' {
+			seg, next, err := parseKey(position, i)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+			i = next
+		}
+
+		for i < len(position) && position[i] == '[' {
+			seg, next, err := parseBracket(position, i)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+			i = next
+		}
+
+		if i == len(position) {
+			return segments, nil
+		}
+
+		if position[i] != '.' {
+			return nil, syntaxError(position, i, "expected . or [ after ]")
+		}
+		i++
+	}
+}
+
+// splitPlain breaks a position without brackets and escapes separately by . (dot),
+// it is the fast path of parsePosition for positions like advert.status.ttl.
+func splitPlain(position string) []segment {
+	return appendPlain(make([]segment, 0, strings.Count(position, ".")+1), position)
+}
+
+// appendPlain appends the segments of a position without brackets and escapes to segments.
+func appendPlain(segments []segment, position string) []segment {
+	for {
+		i := strings.IndexByte(position, '.')
+		if i < 0 {
+			return append(segments, keySegment(position))
+		}
+		segments = append(segments, keySegment(position[:i]))
+		position = position[i+1:]
+	}
+}
+
+// appendPosition appends the segments of position to segments like splitPosition,
+// plain positions don't allocate while segments has capacity for them.
+// It returns nil if the position is not valid.
+func appendPosition(segments []segment, position string) []segment {
+	if isPointer(position) || strings.ContainsAny(position, "[\\") {
+		return splitPosition(position)
+	}
+	return appendPlain(segments, position)
+}
+
+// keySegment returns the segment of a key without escapes, it can be an index or a wildcard.
+func keySegment(key string) segment {
+	seg := segment{key: key, wildcard: key == "*", recursive: key == "**"}
+	seg.index, seg.isIndex = parseIndex(key)
+	return seg
+}
+
+// parseKey parses the key that starts at offset i until the next . (dot) or [ that is not escaped,
+// it returns the segment and the offset after the key.
+func parseKey(position string, i int) (segment, int, error) {
+	start, escaped := i, false
+	for ; i < len(position) && position[i] != '.' && position[i] != '['; i++ {
+		if position[i] == '\\' {
+			if i+1 == len(position) {
+				return segment{}, 0, syntaxError(position, i, "escape \\ at the end of position")
+			}
+			escaped = true
+			i++
+		}
+	}
+
+	if escaped {
+		var b strings.Builder
+		for j := start; j < i; j++ {
+			if position[j] == '\\' {
+				j++
+			}
+			b.WriteByte(position[j])
+		}
+		return segment{key: b.String()}, i, nil
+	}
+
+	return keySegment(position[start:i]), i, nil
+}
+
+// parseIndex returns key as index if it is an integer with optional sign.
+func parseIndex(key string) (int, bool) {
+	digits := key
+	if digits != "" && (digits[0] == '+' || digits[0] == '-') {
+		digits = digits[1:]
+	}
+	if digits == "" {
+		return 0, false
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return 0, false
+		}
+	}
+
+	index, err := strconv.Atoi(key)
+	return index, err == nil
+}
+
+// parseBracket parses the bracket that starts at offset i, it can be an index ([0], [-1]),
+// a wildcard ([*]) or a quoted key (["b.c"], ['b.c']). It returns the segment and the offset after ].
+func parseBracket(position string, i int) (segment, int, error) {
+	open := i
+	i++
+
+	if i < len(position) && (position[i] == '"' || position[i] == '\'') {
+		quote := position[i]
+
+		var b strings.Builder
+		for i++; i < len(position) && position[i] != quote; i++ {
+			if position[i] == '\\' && i+1 < len(position) {
+				i++
+			}
+			b.WriteByte(position[i])
+		}
+
+		if i == len(position) {
+			return segment{}, 0, syntaxError(position, open, "missing closing quote")
+		}
+		if i+1 == len(position) || position[i+1] != ']' {
+			return segment{}, 0, syntaxError(position, i+1, "expected ] after quoted key")
+		}
+		return segment{key: b.String()}, i + 2, nil
+	}
+
+	end := strings.IndexByte(position[i:], ']')
+	if end < 0 {
+		return segment{}, 0, syntaxError(position, open, "missing ]")
+	}
+
+	text := position[i : i+end]
+	if text == "*" {
+		return segment{key: "*", wildcard: true}, i + end + 1, nil
+	}
+
+	index, err := strconv.Atoi(text)
+	if err != nil {
+		return segment{}, 0, syntaxError(position, i, fmt.Sprintf("invalid index %q", text))
+	}
+	return segment{key: text, index: index, isIndex: true}, i + end + 1, nil
+}
+
+// escapeKey returns key with \ before . (dot), [ and \, before the keys * and ** and before
+// the / (slash) at the start of key, so it can be used in a position and parsed back as the same key.
+func escapeKey(key string) string {
+	if key == "*" || key == "**" || isPointer(key) {
+		return "\\" + key
+	}
+
+	if !strings.ContainsAny(key, ".[\\") {
+		return key
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		switch key[i] {
+		case '.', '[', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteByte(key[i])
+	}
+	return b.String()
+}
+
+// joinSegments returns the keys of segments escaped and separately by . (dot).
+func joinSegments(pos []segment) string {
+	keys := make([]string, len(pos))
+	for i, seg := range pos {
+		keys[i] = escapeKey(seg.key)
+	}
+	return strings.Join(keys, ".")
+}
+
+// child returns the value inside of node addressed by seg,
+// node can be any map with keys that can be converted from string, a slice or an array.
+func child(node interface{}, seg segment) (interface{}, bool) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		v, ok := n[seg.key]
+		return v, ok
+	case Map:
+		v, ok := n[seg.key]
+		return v, ok
+	case map[string]string:
+		v, ok := n[seg.key]
+		return v, ok
+	case map[interface{}]interface{}:
+		if v, ok := n[seg.key]; ok {
+			return v, true
+		}
+		for k, v := range n {
+			if fmt.Sprint(k) == seg.key {
+				return v, true
+			}
+		}
+		return nil, false
+	case []interface{}:
+		index, ok := sliceIndex(seg, len(n))
+		if !ok {
+			return nil, false
+		}
+		return n[index], true
+	}
+
+	rv := reflect.ValueOf(node)
+	switch rv.Kind() {
+	case reflect.Map:
+		key, ok := mapKey(rv, seg.key)
+		if !ok {
+			return nil, false
+		}
+
+		v := rv.MapIndex(key)
+		if !v.IsValid() {
+			return nil, false
+		}
+		return v.Interface(), true
+	case reflect.Slice, reflect.Array:
+		index, ok := sliceIndex(seg, rv.Len())
+		if !ok {
+			return nil, false
+		}
+		return rv.Index(index).Interface(), true
+	}
+
+	return nil, false
+}
+
+// mapKey returns the key of map rv for the segment key, the key is converted to
+// the string or numeric type of map keys. For other key types, like interface{},
+// it looks for an existing key that is printed as key.
+func mapKey(rv reflect.Value, key string) (reflect.Value, bool) {
+	keyType := rv.Type().Key()
+
+	switch keyType.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(key).Convert(keyType), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(key, 10, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		return reflect.ValueOf(i).Convert(keyType), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(key, 10, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		return reflect.ValueOf(u).Convert(keyType), true
+	}
+
+	if keyType.Kind() == reflect.Interface {
+		if k := reflect.ValueOf(key); rv.MapIndex(k).IsValid() {
+			return k, true
+		}
+	}
+
+	iter := rv.MapRange()
+	for iter.Next() {
+		if fmt.Sprint(iter.Key().Interface()) == key {
+			return iter.Key(), true
+		}
+	}
+
+	if keyType.Kind() == reflect.Interface {
+		return reflect.ValueOf(key), true
+	}
+
+	return reflect.Value{}, false
+}
+
+// sliceIndex returns the absolute index for seg in a slice with size length,
+// negative indexes are counted from the end of the slice.
+func sliceIndex(seg segment, length int) (int, bool) {
+	if !seg.isIndex {
+		return 0, false
+	}
+
+	index := seg.index
+	if index < 0 {
+		index += length
+	}
+
+	if index < 0 || index >= length {
+		return 0, false
+	}
+
+	return index, true
+}
+
+// isSlice returns true if value is a slice or an array.
+func isSlice(value interface{}) bool {
+	if _, ok := value.([]interface{}); ok {
+		return true
+	}
+
+	kind := reflect.ValueOf(value).Kind()
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// isContainer returns true if value can have nested values, it is a map, a slice or an array.
+func isContainer(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, Map, []interface{}:
+		return true
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
+
+// walk goes through pos starting from node until the last segment, with loose it also stops at the first value
+// that is not a map or a slice (see Strict). It returns the index of the segment not found or -1 if found.
+func walk(node interface{}, pos []segment, loose bool) (interface{}, int) {
+	if len(pos) == 0 {
+		return nil, 0
+	}
+
+	for key, posKey := range pos {
+		v, ok := child(node, posKey)
+		if !ok {
+			return nil, key
+		}
+
+		if loose && !isContainer(v) {
+			return v, -1
+		}
+
+		node = v
+	}
+
+	return node, -1
+}
@@ -0,0 +1,225 @@
+package nested
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSplitPosition(t *testing.T) {
+	tests := []struct {
+		Parameter string
+		Expected  []segment
+	}{
+		{
+			Parameter: "advert.id",
+			Expected:  []segment{{key: "advert"}, {key: "id"}},
+		},
+		{
+			Parameter: "phones.0",
+			Expected:  []segment{{key: "phones"}, {key: "0", index: 0, isIndex: true}},
+		},
+		{
+			Parameter: "phones[-1]",
+			Expected:  []segment{{key: "phones"}, {key: "-1", index: -1, isIndex: true}},
+		},
+		{
+			Parameter: "matrix[1][2].name",
+			Expected: []segment{
+				{key: "matrix"},
+				{key: "1", index: 1, isIndex: true},
+				{key: "2", index: 2, isIndex: true},
+				{key: "name"},
+			},
+		},
+		{
+			Parameter: "items.*.price",
+			Expected:  []segment{{key: "items"}, {key: "*", wildcard: true}, {key: "price"}},
+		},
+		{
+			Parameter: "items[*].**",
+			Expected:  []segment{{key: "items"}, {key: "*", wildcard: true}, {key: "**", recursive: true}},
+		},
+		{
+			Parameter: "phones[a]",
+			Expected:  nil,
+		},
+		{
+			Parameter: "phones[0",
+			Expected:  nil,
+		},
+		{
+			Parameter: "phones[0]x",
+			Expected:  nil,
+		},
+		{
+			Parameter: `labels.k8s\.io/name`,
+			Expected:  []segment{{key: "labels"}, {key: "k8s.io/name"}},
+		},
+		{
+			Parameter: `labels["k8s.io/name"].value`,
+			Expected:  []segment{{key: "labels"}, {key: "k8s.io/name"}, {key: "value"}},
+		},
+		{
+			Parameter: `labels['a\'b'][0]`,
+			Expected:  []segment{{key: "labels"}, {key: "a'b"}, {key: "0", index: 0, isIndex: true}},
+		},
+		{
+			Parameter: `items.\*.\0`,
+			Expected:  []segment{{key: "items"}, {key: "*"}, {key: "0"}},
+		},
+		{
+			Parameter: `a\\.b\[0]`,
+			Expected:  []segment{{key: `a\`}, {key: "b[0]"}},
+		},
+		{
+			Parameter: "a..b.",
+			Expected:  []segment{{key: "a"}, {key: ""}, {key: "b"}, {key: ""}},
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual := splitPosition(test.Parameter)
+			if !reflect.DeepEqual(test.Expected, actual) {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		Parameter string
+		Column    int
+	}{
+		{Parameter: "phones[a]", Column: 8},
+		{Parameter: "phones[0", Column: 7},
+		{Parameter: "phones[0]x", Column: 10},
+		{Parameter: `labels["name`, Column: 7},
+		{Parameter: `labels["name"x]`, Column: 14},
+		{Parameter: `labels.name\`, Column: 12},
+		{Parameter: "/labels/a~2", Column: 10},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			_, err := ParsePath(test.Parameter)
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("[%s] expected *SyntaxError, but got %v", test.Parameter, err)
+			}
+			if syntaxErr.Column != test.Column {
+				t.Errorf("[%s] expected column %d, but got %d", test.Parameter, test.Column, syntaxErr.Column)
+			}
+			if !errors.Is(err, ErrInvalidPosition) {
+				t.Errorf("[%s] expected error %v, but got %v", test.Parameter, ErrInvalidPosition, err)
+			}
+		})
+	}
+
+	path, err := ParsePath(`labels["k8s.io/name"][0]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := path.Keys(); !reflect.DeepEqual(keys, []string{"labels", "k8s.io/name", "0"}) {
+		t.Errorf("unexpected keys %v", keys)
+	}
+	if path.Position() != `labels["k8s.io/name"][0]` {
+		t.Errorf("unexpected position %q", path.Position())
+	}
+}
+
+func TestEscapeKey(t *testing.T) {
+	for _, key := range []string{"name", "k8s.io/name", `a\b`, "b[0]", "*", "**", "/root", "", "1.2.0"} {
+		pos, err := parsePosition("labels." + escapeKey(key))
+		if err != nil {
+			t.Fatalf("[%s] unexpected error %v", key, err)
+		}
+		if len(pos) != 2 || pos[1].key != key || pos[1].wildcard || pos[1].recursive {
+			t.Errorf("[%s] expected key back, but got %v", key, pos)
+		}
+	}
+}
+
+type labelKey string
+
+func TestChild(t *testing.T) {
+	tests := []struct {
+		Node           interface{}
+		Segment        segment
+		ExpectedFirst  interface{}
+		ExpectedSecond bool
+	}{
+		{
+			Node:           map[labelKey]int{"app": 1},
+			Segment:        segment{key: "app"},
+			ExpectedFirst:  1,
+			ExpectedSecond: true,
+		},
+		{
+			Node:           map[uint8]string{7: "seven"},
+			Segment:        segment{key: "7", index: 7, isIndex: true},
+			ExpectedFirst:  "seven",
+			ExpectedSecond: true,
+		},
+		{
+			Node:           map[uint8]string{7: "seven"},
+			Segment:        segment{key: "700", index: 700, isIndex: true},
+			ExpectedFirst:  nil,
+			ExpectedSecond: false,
+		},
+		{
+			Node:           map[interface{}]string{true: "yes"},
+			Segment:        segment{key: "true"},
+			ExpectedFirst:  "yes",
+			ExpectedSecond: true,
+		},
+		{
+			Node:           [2]string{"first", "second"},
+			Segment:        segment{key: "-1", index: -1, isIndex: true},
+			ExpectedFirst:  "second",
+			ExpectedSecond: true,
+		},
+		{
+			Node:           "Rodrigo",
+			Segment:        segment{key: "0", index: 0, isIndex: true},
+			ExpectedFirst:  nil,
+			ExpectedSecond: false,
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, result := child(test.Node, test.Segment)
+			if !reflect.DeepEqual(test.ExpectedFirst, actual) || result != test.ExpectedSecond {
+				t.Errorf("[%T] expected %v and %v, but got %v and %v",
+					test.Node, test.ExpectedFirst, test.ExpectedSecond, actual, result)
+			}
+		})
+	}
+}
+
+func ExampleParsePath() {
+	data, _ := NewFromJSON(`{"labels": {"k8s.io/name": "api", "version": {"1.2": "stable"}}}`)
+
+	path, err := ParsePath(`labels["k8s.io/name"]`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(path.Keys())
+	fmt.Println(data.GetString(path.Position()))
+	fmt.Println(data.GetString(`labels.version.1\.2`))
+
+	_, err = ParsePath("labels[0")
+	fmt.Println(err)
+	// output:
+	// [labels k8s.io/name]
+	// api
+	// stable
+	// invalid position "labels[0" at column 7: missing ]
+}
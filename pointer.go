@@ -0,0 +1,133 @@
+package nested
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pointerEscaper escapes the keys of a JSON Pointer, ~ is written as ~0 and / as ~1.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// pointerUnescaper unescapes the keys of a JSON Pointer, ~1 is read as / and ~0 as ~.
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// isPointer returns true if position is a JSON Pointer (RFC 6901), it starts with / (slash).
+func isPointer(position string) bool {
+	return strings.HasPrefix(position, "/")
+}
+
+// splitPointer breaks a JSON Pointer like /a/b~1c/0 into segments,
+// segments with only digits and without leading zeros are indexes for slices.
+// It returns *SyntaxError if the pointer has a ~ that is not followed by 0 or 1.
+func splitPointer(pointer string) ([]segment, error) {
+	parts := strings.Split(pointer[1:], "/")
+	segments := make([]segment, 0, len(parts))
+
+	offset := 1
+	for _, part := range parts {
+		for i := 0; i < len(part); i++ {
+			if part[i] == '~' && (i+1 == len(part) || (part[i+1] != '0' && part[i+1] != '1')) {
+				return nil, syntaxError(pointer, offset+i, "~ must be followed by 0 or 1")
+			}
+		}
+		offset += len(part) + 1
+
+		seg := segment{key: pointerUnescaper.Replace(part)}
+		if isPointerIndex(part) {
+			seg.index, _ = strconv.Atoi(part)
+			seg.isIndex = true
+		}
+		segments = append(segments, seg)
+	}
+
+	return segments, nil
+}
+
+// isPointerIndex returns true if part is an array index of JSON Pointer, 0 or digits without leading zero.
+func isPointerIndex(part string) bool {
+	if part == "" || (part[0] == '0' && len(part) > 1) {
+		return false
+	}
+
+	for i := 0; i < len(part); i++ {
+		if part[i] < '0' || part[i] > '9' {
+			return false
+		}
+	}
+
+	_, err := strconv.Atoi(part)
+	return err == nil
+}
+
+// GetPointer returns the value from JSON Pointer that you passed by argument
+func (m Map) GetPointer(pointer string) interface{} {
+	value, _ := m.Pointer(pointer)
+	return value
+}
+
+// Pointer returns the value from JSON Pointer (RFC 6901) like /a/b~1c/0, where ~1 is / and ~0 is ~ in the keys,
+// the empty pointer returns the Map itself. Any function that receives a position also accepts
+// a JSON Pointer when the position starts with / (slash).
+// if the field is not found or the pointer is not valid it returns nil and false.
+func (m Map) Pointer(pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return m, true
+	}
+
+	if !isPointer(pointer) {
+		return nil, false
+	}
+
+	return m.Interface(pointer)
+}
+
+// ToPointer converts a position separately by . (dot) to JSON Pointer, like person.phones[0] to /person/phones/0,
+// it returns *SyntaxError if position is malformed or ErrInvalidPosition if it has negative indexes.
+// JSON Pointers are returned as they are.
+func ToPointer(position string) (string, error) {
+	if isPointer(position) {
+		return position, nil
+	}
+
+	pos, err := parsePosition(position)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, seg := range pos {
+		if seg.isIndex && seg.index < 0 {
+			return "", fmt.Errorf("%w: negative index in %q", ErrInvalidPosition, position)
+		}
+
+		b.WriteByte('/')
+		b.WriteString(pointerEscaper.Replace(seg.key))
+	}
+	return b.String(), nil
+}
+
+// FromPointer converts a JSON Pointer to position separately by . (dot), like /person/phones/0 to person.phones.0,
+// the keys with . (dot) are escaped like /labels/k8s.io~1name to labels.k8s\.io/name.
+// It returns *SyntaxError if pointer is not valid.
+func FromPointer(pointer string) (string, error) {
+	if !isPointer(pointer) {
+		return "", syntaxError(pointer, 0, "json pointer must start with /")
+	}
+
+	pos, err := splitPointer(pointer)
+	if err != nil {
+		return "", err
+	}
+	return joinSegments(pos), nil
+}
+
+// Pointer is helper for function Pointer from Map.
+func Pointer(pointer string, mapper map[string]interface{}) (interface{}, bool) {
+	return New(mapper).Pointer(pointer)
+}
+
+// GetPointer is helper for function GetPointer from Map.
+func GetPointer(pointer string, mapper map[string]interface{}) interface{} {
+	return New(mapper).GetPointer(pointer)
+}
@@ -0,0 +1,156 @@
+package nested
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPointer(t *testing.T) {
+	data := map[string]interface{}{
+		"labels": map[string]interface{}{
+			"k8s.io/name": "api",
+			"a~b":         "tilde",
+			"":            "empty",
+		},
+		"versions": map[string]interface{}{
+			"1.2.0": map[string]interface{}{"released": "2020-02-13T00:00:00Z"},
+		},
+		"phones": []interface{}{"111", "222"},
+		"00":     "zeros",
+	}
+
+	tests := []struct {
+		Parameter string
+		Expected  interface{}
+		Found     bool
+	}{
+		{Parameter: "/labels/k8s.io~1name", Expected: "api", Found: true},
+		{Parameter: "/labels/a~0b", Expected: "tilde", Found: true},
+		{Parameter: "/labels/", Expected: "empty", Found: true},
+		{Parameter: "/versions/1.2.0/released", Expected: "2020-02-13T00:00:00Z", Found: true},
+		{Parameter: "/phones/1", Expected: "222", Found: true},
+		{Parameter: "/phones/01", Expected: nil, Found: false},
+		{Parameter: "/phones/-1", Expected: nil, Found: false},
+		{Parameter: "/phones/-", Expected: nil, Found: false},
+		{Parameter: "/00", Expected: "zeros", Found: true},
+		{Parameter: "/labels/a~2b", Expected: nil, Found: false},
+		{Parameter: "/labels/a~", Expected: nil, Found: false},
+		{Parameter: "/labels/missing", Expected: nil, Found: false},
+		{Parameter: "labels", Expected: nil, Found: false},
+		{Parameter: "", Expected: Map(data), Found: true},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, found := Pointer(test.Parameter, data)
+			if found != test.Found {
+				t.Errorf("[%s] expected found %v, but got %v", test.Parameter, test.Found, found)
+			}
+			if !reflect.DeepEqual(test.Expected, actual) {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestPointerPosition(t *testing.T) {
+	data := New(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"k8s.io/replicas": 3,
+			"created.at":      "2020-02-13",
+		},
+		"items": []interface{}{map[string]interface{}{"price": "x"}},
+	})
+
+	if value, _ := data.Int("/metadata/k8s.io~1replicas"); value != 3 {
+		t.Errorf("expected 3, but got %v", value)
+	}
+
+	if value := data.GetTime("/metadata/created.at", "2006-01-02"); !value.Equal(time.Date(2020, 2, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 2020-02-13, but got %v", value)
+	}
+
+	if err := data.Set("/metadata/k8s.io~1name", "api"); err != nil {
+		t.Fatal(err)
+	}
+	if value := data.GetString("/metadata/k8s.io~1name"); value != "api" {
+		t.Errorf("expected api, but got %v", value)
+	}
+
+	var notFound *PathNotFoundError
+	if _, err := data.StringE("/metadata/missing"); !errors.As(err, &notFound) || notFound.MissingSegment != "missing" {
+		t.Errorf("expected *PathNotFoundError for missing, but got %v", err)
+	}
+
+	var mismatch *TypeMismatchError
+	if err := data.Decode("/items", &[]struct{ Price int }{}); !errors.As(err, &mismatch) || mismatch.Path != "/items/0/Price" {
+		t.Errorf("expected *TypeMismatchError for /items/0/Price, but got %v", err)
+	}
+}
+
+func TestToPointer(t *testing.T) {
+	tests := []struct {
+		Parameter string
+		Expected  string
+		Err       error
+	}{
+		{Parameter: "person.phones[0]", Expected: "/person/phones/0"},
+		{Parameter: "labels.a/b.c~d", Expected: "/labels/a~1b/c~0d"},
+		{Parameter: "/already/pointer", Expected: "/already/pointer"},
+		{Parameter: "phones[-1]", Err: ErrInvalidPosition},
+		{Parameter: "phones[", Err: ErrInvalidPosition},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, err := ToPointer(test.Parameter)
+			if !errors.Is(err, test.Err) {
+				t.Errorf("[%s] expected error %v, but got %v", test.Parameter, test.Err, err)
+			}
+			if actual != test.Expected {
+				t.Errorf("[%s] expected %q, but got %q", test.Parameter, test.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestFromPointer(t *testing.T) {
+	tests := []struct {
+		Parameter string
+		Expected  string
+		Err       error
+	}{
+		{Parameter: "/person/phones/0", Expected: "person.phones.0"},
+		{Parameter: "/labels/a~1b/c~0d", Expected: "labels.a/b.c~d"},
+		{Parameter: "/labels/k8s.io~1name", Expected: `labels.k8s\.io/name`},
+		{Parameter: "/~1root", Expected: `\/root`},
+		{Parameter: "/a~", Err: ErrInvalidPosition},
+		{Parameter: "person.name", Err: ErrInvalidPosition},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, err := FromPointer(test.Parameter)
+			if !errors.Is(err, test.Err) {
+				t.Errorf("[%s] expected error %v, but got %v", test.Parameter, test.Err, err)
+			}
+			if actual != test.Expected {
+				t.Errorf("[%s] expected %q, but got %q", test.Parameter, test.Expected, actual)
+			}
+		})
+	}
+}
+
+func ExampleMap_Pointer() {
+	data, _ := NewFromJSON(`{"labels": {"k8s.io/name": "api"}, "versions": {"1.2.0": "stable"}}`)
+
+	name, _ := data.Pointer("/labels/k8s.io~1name")
+	fmt.Println(name)
+	fmt.Println(data.GetString("/versions/1.2.0"))
+	// output:
+	// api
+	// stable
+}
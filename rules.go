@@ -0,0 +1,270 @@
+package nested
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// Rules are the rules of validation by position, like
+// Rules{"person.name": Required().String().MaxLen(50), "session.expire": Required().Time(time.RFC3339).Future()}.
+type Rules map[string]*Rule
+
+// Rule is the validation of a position built by Required or Optional, the type like String, Int or Time
+// converts the value with the getters of Map and the checks after it use the value converted.
+type Rule struct {
+	required bool
+	typ      string
+	typeMsg  string
+	get      func(m Map, position string) (interface{}, error)
+	checks   []ruleCheck
+}
+
+// ruleCheck is a check of Rule, fn returns the message of violation or "" when the value is valid.
+type ruleCheck struct {
+	name string
+	fn   func(value interface{}) string
+}
+
+// Required returns a Rule where the position must exist and not be null.
+func Required() *Rule {
+	return &Rule{required: true}
+}
+
+// Optional returns a Rule where the position is validated only when it exists and it is not null.
+func Optional() *Rule {
+	return &Rule{}
+}
+
+// String makes the value a string like Map.String.
+func (r *Rule) String() *Rule {
+	return r.as("string", "must be a string", func(m Map, position string) (interface{}, error) {
+		return m.StringE(position)
+	})
+}
+
+// Int makes the value an int converted like Map.Int.
+func (r *Rule) Int(opts ...Option) *Rule {
+	return r.as("int", "must be an int", func(m Map, position string) (interface{}, error) {
+		return m.IntE(position, opts...)
+	})
+}
+
+// Float64 makes the value a float64 converted like Map.Float64.
+func (r *Rule) Float64(opts ...Option) *Rule {
+	return r.as("float64", "must be a number", func(m Map, position string) (interface{}, error) {
+		return m.Float64E(position, opts...)
+	})
+}
+
+// Bool makes the value a bool like Map.Bool.
+func (r *Rule) Bool() *Rule {
+	return r.as("bool", "must be a bool", func(m Map, position string) (interface{}, error) {
+		return m.BoolE(position)
+	})
+}
+
+// Time makes the value a time.Time parsed with layout like Map.Time.
+func (r *Rule) Time(layout string) *Rule {
+	return r.as("time", fmt.Sprintf("must be a time with layout %s", layout), func(m Map, position string) (interface{}, error) {
+		return m.TimeE(position, layout)
+	})
+}
+
+// as sets the type of Rule, msg is the message of violation when the value cannot be converted.
+func (r *Rule) as(typ, msg string, get func(m Map, position string) (interface{}, error)) *Rule {
+	r.typ, r.typeMsg, r.get = typ, msg, get
+	return r
+}
+
+// MinLen checks that the string has at least n characters or the slice has at least n elements.
+func (r *Rule) MinLen(n int) *Rule {
+	return r.check("minLen", func(value interface{}) string {
+		length, unit, ok := ruleLength(value)
+		if !ok {
+			return "must be a string or a slice"
+		}
+		if length < n {
+			return fmt.Sprintf("must have at least %d %s", n, unit)
+		}
+		return ""
+	})
+}
+
+// MaxLen checks that the string has at most n characters or the slice has at most n elements.
+func (r *Rule) MaxLen(n int) *Rule {
+	return r.check("maxLen", func(value interface{}) string {
+		length, unit, ok := ruleLength(value)
+		if !ok {
+			return "must be a string or a slice"
+		}
+		if length > n {
+			return fmt.Sprintf("must have at most %d %s", n, unit)
+		}
+		return ""
+	})
+}
+
+// Min checks that the number is greater than or equal to min.
+func (r *Rule) Min(min float64) *Rule {
+	return r.check("min", func(value interface{}) string {
+		number, err := toFloat64(value, options{})
+		if err != nil {
+			return "must be a number"
+		}
+		if number < min {
+			return fmt.Sprintf("must be >= %v", min)
+		}
+		return ""
+	})
+}
+
+// Max checks that the number is less than or equal to max.
+func (r *Rule) Max(max float64) *Rule {
+	return r.check("max", func(value interface{}) string {
+		number, err := toFloat64(value, options{})
+		if err != nil {
+			return "must be a number"
+		}
+		if number > max {
+			return fmt.Sprintf("must be <= %v", max)
+		}
+		return ""
+	})
+}
+
+// Pattern checks that the string matches the regular expression expr, it panics if expr is not valid like regexp.MustCompile.
+func (r *Rule) Pattern(expr string) *Rule {
+	pattern := regexp.MustCompile(expr)
+	return r.check("pattern", func(value interface{}) string {
+		text, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		if !pattern.MatchString(text) {
+			return fmt.Sprintf("must match the pattern %s", expr)
+		}
+		return ""
+	})
+}
+
+// OneOf checks that the value is equal to one of values, numbers are compared by value.
+func (r *Rule) OneOf(values ...interface{}) *Rule {
+	return r.check("oneOf", func(value interface{}) string {
+		if !inEnum(values, value) {
+			return fmt.Sprintf("must be one of %v", values)
+		}
+		return ""
+	})
+}
+
+// Future checks that the time is after now.
+func (r *Rule) Future() *Rule {
+	return r.check("future", func(value interface{}) string {
+		t, ok := value.(time.Time)
+		if !ok {
+			return "must be a time"
+		}
+		if !t.After(time.Now()) {
+			return "must be in the future"
+		}
+		return ""
+	})
+}
+
+// Past checks that the time is before now.
+func (r *Rule) Past() *Rule {
+	return r.check("past", func(value interface{}) string {
+		t, ok := value.(time.Time)
+		if !ok {
+			return "must be a time"
+		}
+		if !t.Before(time.Now()) {
+			return "must be in the past"
+		}
+		return ""
+	})
+}
+
+// Check adds the custom check name, fn returns an error with the message of violation when the value is not valid.
+func (r *Rule) Check(name string, fn func(value interface{}) error) *Rule {
+	return r.check(name, func(value interface{}) string {
+		if err := fn(value); err != nil {
+			return err.Error()
+		}
+		return ""
+	})
+}
+
+// check appends the check name to Rule.
+func (r *Rule) check(name string, fn func(value interface{}) string) *Rule {
+	r.checks = append(r.checks, ruleCheck{name: name, fn: fn})
+	return r
+}
+
+// ruleLength returns the number of characters of string or elements of slice value.
+func ruleLength(value interface{}) (int, string, bool) {
+	switch valueKind(value) {
+	case "string":
+		return utf8.RuneCountInString(fmt.Sprint(value)), "characters", true
+	case "slice":
+		items, _ := toSlice(value)
+		return len(items), "elements", true
+	}
+	return 0, "", false
+}
+
+// Validate checks the positions of Map with rules, it returns *ValidationError with all violations
+// sorted by position. A rule stops in the first check that fails, the nil rules are ignored.
+func (m Map) Validate(rules Rules) error {
+	positions := make([]string, 0, len(rules))
+	for position, rule := range rules {
+		if rule != nil {
+			positions = append(positions, position)
+		}
+	}
+	sort.Strings(positions)
+
+	var violations []Violation
+	for _, position := range positions {
+		if v, ok := rules[position].validate(m, position); !ok {
+			violations = append(violations, v)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// validate returns the violation of rule for position of m and false if the value is not valid.
+func (r *Rule) validate(m Map, position string) (Violation, bool) {
+	value, err := m.InterfaceE(position)
+	if err != nil || value == nil {
+		if r.required {
+			return Violation{Path: position, Rule: "required", Message: "is required"}, false
+		}
+		return Violation{}, true
+	}
+
+	if r.get != nil {
+		if value, err = r.get(m, position); err != nil {
+			return Violation{Path: position, Rule: r.typ, Message: r.typeMsg}, false
+		}
+	}
+
+	for _, c := range r.checks {
+		if msg := c.fn(value); msg != "" {
+			return Violation{Path: position, Rule: c.name, Message: msg}, false
+		}
+	}
+	return Violation{}, true
+}
+
+// Validate is helper for function Validate from Map.
+func Validate(mapper map[string]interface{}, rules Rules) error {
+	return New(mapper).Validate(rules)
+}
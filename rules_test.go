@@ -0,0 +1,138 @@
+package nested
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	m := New(map[string]interface{}{
+		"person": map[string]interface{}{
+			"name":  "daniel",
+			"level": 3,
+			"price": "19.9",
+			"role":  "admin",
+			"tags":  []interface{}{"a", "b", "c"},
+			"admin": "yes",
+		},
+		"session": map[string]interface{}{
+			"expire":  "2999-01-01T00:00:00Z",
+			"created": "2020-02-13T21:42:03Z",
+			"token":   nil,
+		},
+	})
+
+	tests := []struct {
+		Rules    Rules
+		Expected []Violation
+	}{
+		{
+			Rules: Rules{
+				"person.name":    Required().String().MaxLen(50),
+				"person.level":   Required().Int().Min(1).Max(5),
+				"person.price":   Optional().Float64(Lenient()).Min(10),
+				"person.role":    Required().OneOf("admin", "user"),
+				"person.tags":    Optional().MinLen(1),
+				"person.missing": Optional().String(),
+				"session.token":  Optional().String(),
+				"session.expire": Required().Time(time.RFC3339).Future(),
+			},
+			Expected: nil,
+		},
+		{
+			Rules: Rules{
+				"person.name":     Required().String().MinLen(10),
+				"person.level":    Required().Int().Max(2),
+				"person.price":    Required().Float64(),
+				"person.role":     Required().OneOf("user"),
+				"person.tags":     Required().MaxLen(2),
+				"person.admin":    Required().Bool(),
+				"person.missing":  Required().String(),
+				"session.token":   Required(),
+				"session.created": Required().Time(time.RFC3339).Future(),
+				"session.expire":  Required().Time("2006-01-02").Past(),
+			},
+			Expected: []Violation{
+				{Path: "person.admin", Rule: "bool", Message: "must be a bool"},
+				{Path: "person.level", Rule: "max", Message: "must be <= 2"},
+				{Path: "person.missing", Rule: "required", Message: "is required"},
+				{Path: "person.name", Rule: "minLen", Message: "must have at least 10 characters"},
+				{Path: "person.price", Rule: "float64", Message: "must be a number"},
+				{Path: "person.role", Rule: "oneOf", Message: "must be one of [user]"},
+				{Path: "person.tags", Rule: "maxLen", Message: "must have at most 2 elements"},
+				{Path: "session.created", Rule: "future", Message: "must be in the future"},
+				{Path: "session.expire", Rule: "time", Message: "must be a time with layout 2006-01-02"},
+				{Path: "session.token", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			Rules: Rules{
+				"person.name":  Required().Pattern("^[a-z]+$").Check("notRoot", notRoot),
+				"person.level": Required().String(),
+				"person.role":  Required().Check("notRoot", notRoot),
+			},
+			Expected: []Violation{
+				{Path: "person.level", Rule: "string", Message: "must be a string"},
+				{Path: "person.role", Rule: "notRoot", Message: "admin is not allowed"},
+			},
+		},
+		{
+			Rules: Rules{
+				"person.name":    nil,
+				"person.missing": nil,
+				"person.level":   Required().String(),
+			},
+			Expected: []Violation{
+				{Path: "person.level", Rule: "string", Message: "must be a string"},
+			},
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			err := m.Validate(test.Rules)
+
+			var actual []Violation
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				actual = validationErr.Violations
+			} else if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if !reflect.DeepEqual(test.Expected, actual) {
+				t.Errorf("expected %v, but got %v", test.Expected, actual)
+			}
+		})
+	}
+}
+
+func notRoot(value interface{}) error {
+	if value == "admin" || value == "root" {
+		return fmt.Errorf("%v is not allowed", value)
+	}
+	return nil
+}
+
+func ExampleMap_Validate() {
+	m, _ := NewFromJSON(`{"person": {"name": "daniel"}, "session": {"expire": "2020-02-13T21:42:03Z"}}`)
+
+	err := m.Validate(Rules{
+		"person.name":    Required().String().MaxLen(50),
+		"person.level":   Required().Int().Min(1),
+		"session.expire": Required().Time(time.RFC3339).Future(),
+	})
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		for _, v := range validationErr.Violations {
+			fmt.Println(v)
+		}
+	}
+	// output:
+	// person.level: is required
+	// session.expire: must be in the future
+}
@@ -0,0 +1,300 @@
+package nested
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Schema is a JSON Schema (draft 2020-12) with the keywords type, required, properties, items, enum,
+// pattern, format and the limits minimum, maximum, minLength, maxLength, minItems and maxItems.
+// The other keywords are ignored.
+type Schema struct {
+	Type       SchemaTypes        `json:"type,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Enum       []interface{}      `json:"enum,omitempty"`
+	Pattern    string             `json:"pattern,omitempty"`
+	Format     string             `json:"format,omitempty"`
+
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+	MinLength        *int     `json:"minLength,omitempty"`
+	MaxLength        *int     `json:"maxLength,omitempty"`
+	MinItems         *int     `json:"minItems,omitempty"`
+	MaxItems         *int     `json:"maxItems,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// SchemaTypes are the types of keyword type: object, array, string, number, integer, boolean or null,
+// in JSON it can be a string or an array of strings.
+type SchemaTypes []string
+
+// UnmarshalJSON reads the type as a string or an array of strings.
+func (t *SchemaTypes) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*t = SchemaTypes{name}
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(b, &names); err != nil {
+		return err
+	}
+	*t = names
+	return nil
+}
+
+// NewSchema returns the Schema from schema, like the result of NewFromJSON,
+// it returns *ParseError if schema is not a valid JSON Schema or has an invalid pattern.
+func NewSchema(schema Map) (*Schema, error) {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, &ParseError{Layout: "json schema", Err: err}
+	}
+	return NewSchemaFromJSON(string(b))
+}
+
+// NewSchemaFromJSON returns the Schema from the JSON Schema in string,
+// it returns *ParseError if it is not a valid JSON Schema or has an invalid pattern.
+func NewSchemaFromJSON(schema string) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal([]byte(schema), &s); err != nil {
+		return nil, &ParseError{Layout: "json schema", Err: err}
+	}
+
+	if err := s.compile(""); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// compile compiles the patterns of s and its properties and items, position is the position of s in the schema.
+func (s *Schema) compile(position string) error {
+	if s == nil {
+		return nil
+	}
+
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return &ParseError{Path: keyPosition(position, "pattern"), Layout: "regexp", Err: err}
+		}
+		s.pattern = pattern
+	}
+
+	for name, property := range s.Properties {
+		if err := property.compile(keyPosition(keyPosition(position, "properties"), name)); err != nil {
+			return err
+		}
+	}
+	return s.Items.compile(keyPosition(position, "items"))
+}
+
+// ValidateSchema validates Map with schema, it returns *ValidationError with all violations,
+// the path of each violation is the position of the value like person.phones.0.
+func (m Map) ValidateSchema(schema *Schema) error {
+	var violations []Violation
+	schema.validate(&violations, "", map[string]interface{}(m))
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// validate appends to violations the keywords of s that value in position doesn't follow.
+func (s *Schema) validate(violations *[]Violation, position string, value interface{}) {
+	if s == nil {
+		return
+	}
+
+	add := func(rule, format string, args ...interface{}) {
+		*violations = append(*violations, Violation{Path: position, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.Type) > 0 && !s.hasType(value) {
+		add("type", "must be %s, but it is %s", strings.Join(s.Type, " or "), schemaType(value))
+		return
+	}
+
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		add("enum", "must be one of %v", s.Enum)
+	}
+
+	switch valueKind(value) {
+	case "map":
+		s.validateObject(violations, position, value)
+	case "slice":
+		items, _ := toSlice(value)
+		if s.MinItems != nil && len(items) < *s.MinItems {
+			add("minItems", "must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(items) > *s.MaxItems {
+			add("maxItems", "must have at most %d items", *s.MaxItems)
+		}
+
+		for i, item := range items {
+			s.Items.validate(violations, elementPosition(position, i), item)
+		}
+	case "string":
+		text := fmt.Sprint(value)
+		length := utf8.RuneCountInString(text)
+		if s.MinLength != nil && length < *s.MinLength {
+			add("minLength", "must have at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			add("maxLength", "must have at most %d characters", *s.MaxLength)
+		}
+
+		if s.Pattern != "" {
+			pattern, err := s.pattern, error(nil)
+			if pattern == nil {
+				pattern, err = regexp.Compile(s.Pattern)
+			}
+
+			if err != nil {
+				add("pattern", "cannot be checked with the invalid pattern %s", s.Pattern)
+			} else if !pattern.MatchString(text) {
+				add("pattern", "must match the pattern %s", s.Pattern)
+			}
+		}
+
+		if !validFormat(s.Format, text) {
+			add("format", "must be a valid %s", s.Format)
+		}
+	case "number":
+		number, err := toFloat64(value, options{})
+		if err != nil {
+			return
+		}
+
+		if s.Minimum != nil && number < *s.Minimum {
+			add("minimum", "must be >= %v", *s.Minimum)
+		}
+		if s.Maximum != nil && number > *s.Maximum {
+			add("maximum", "must be <= %v", *s.Maximum)
+		}
+		if s.ExclusiveMinimum != nil && number <= *s.ExclusiveMinimum {
+			add("exclusiveMinimum", "must be > %v", *s.ExclusiveMinimum)
+		}
+		if s.ExclusiveMaximum != nil && number >= *s.ExclusiveMaximum {
+			add("exclusiveMaximum", "must be < %v", *s.ExclusiveMaximum)
+		}
+	}
+}
+
+// validateObject validates the keywords required and properties of map value in position.
+func (s *Schema) validateObject(violations *[]Violation, position string, value interface{}) {
+	for _, name := range s.Required {
+		if _, ok := child(value, segment{key: name}); !ok {
+			*violations = append(*violations, Violation{Path: keyPosition(position, name), Rule: "required", Message: "is required"})
+		}
+	}
+
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if property, ok := child(value, segment{key: name}); ok {
+			s.Properties[name].validate(violations, keyPosition(position, name), property)
+		}
+	}
+}
+
+// hasType returns true if value is one of the types of s.
+func (s *Schema) hasType(value interface{}) bool {
+	kind := schemaType(value)
+	for _, name := range s.Type {
+		if name == kind || (name == "number" && kind == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaType returns the type of value in JSON Schema, numbers without fractional part are integer.
+func schemaType(value interface{}) string {
+	switch kind := valueKind(value); kind {
+	case "map":
+		return "object"
+	case "slice":
+		return "array"
+	case "bool":
+		return "boolean"
+	case "number":
+		if _, err := toInt64(value, options{}); err == nil {
+			return "integer"
+		}
+		return "number"
+	default:
+		return kind
+	}
+}
+
+// inEnum returns true if value is equal to some value of enum.
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, item := range enum {
+		if jsonEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// uuidPattern is the format uuid like 6ba7b810-9dad-11d1-80b4-00c04fd430c8.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// hostnamePattern is the format hostname of RFC 1123.
+var hostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// validFormat returns true if text has the format, the formats that are unknown are valid.
+func validFormat(format, text string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, text)
+		return err == nil
+	case "date":
+		_, err := time.Parse("2006-01-02", text)
+		return err == nil
+	case "time":
+		_, err := time.Parse("15:04:05Z07:00", text)
+		return err == nil
+	case "email":
+		address, err := mail.ParseAddress(text)
+		return err == nil && address.Address == text
+	case "hostname":
+		return len(text) <= 253 && hostnamePattern.MatchString(text)
+	case "ipv4":
+		ip := net.ParseIP(text)
+		return ip != nil && ip.To4() != nil && !strings.Contains(text, ":")
+	case "ipv6":
+		return net.ParseIP(text) != nil && strings.Contains(text, ":")
+	case "uri":
+		u, err := url.Parse(text)
+		return err == nil && u.Scheme != ""
+	case "uuid":
+		return uuidPattern.MatchString(text)
+	}
+	return true
+}
+
+// ValidateSchema is helper for function ValidateSchema from Map.
+func ValidateSchema(mapper map[string]interface{}, schema *Schema) error {
+	return New(mapper).ValidateSchema(schema)
+}
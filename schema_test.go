@@ -0,0 +1,171 @@
+package nested
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+const personSchema = `{
+	"type": "object",
+	"required": ["person", "session"],
+	"properties": {
+		"person": {
+			"type": "object",
+			"required": ["name", "email"],
+			"properties": {
+				"name":   {"type": "string", "minLength": 2, "maxLength": 10},
+				"email":  {"type": "string", "format": "email"},
+				"level":  {"type": "integer", "minimum": 1, "maximum": 5},
+				"score":  {"type": ["number", "null"], "exclusiveMinimum": 0},
+				"role":   {"enum": ["admin", "user"]},
+				"code":   {"type": "string", "pattern": "^[A-Z]{3}$"},
+				"phones": {"type": "array", "maxItems": 2, "items": {"type": "string", "pattern": "^[0-9-]+$"}}
+			}
+		},
+		"session": {
+			"type": "object",
+			"properties": {
+				"expire": {"type": "string", "format": "date-time"},
+				"ip":     {"type": "string", "format": "ipv4"},
+				"id":     {"type": "string", "format": "uuid"}
+			}
+		}
+	}
+}`
+
+func TestValidateSchema(t *testing.T) {
+	schema, err := NewSchemaFromJSON(personSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Data     string
+		Expected []Violation
+	}{
+		{
+			Data: `{"person": {"name": "daniel", "email": "daniel@example.com", "level": 3, "score": null, "role": "admin",
+				"code": "ABC", "phones": ["790-123"]}, "session": {"expire": "2020-02-13T21:42:03Z", "ip": "10.0.0.1",
+				"id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8"}}`,
+			Expected: nil,
+		},
+		{
+			Data: `{}`,
+			Expected: []Violation{
+				{Path: "person", Rule: "required", Message: "is required"},
+				{Path: "session", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			Data: `{"person": {"name": "d", "level": 1.5, "score": 0}, "session": []}`,
+			Expected: []Violation{
+				{Path: "person.email", Rule: "required", Message: "is required"},
+				{Path: "person.level", Rule: "type", Message: "must be integer, but it is number"},
+				{Path: "person.name", Rule: "minLength", Message: "must have at least 2 characters"},
+				{Path: "person.score", Rule: "exclusiveMinimum", Message: "must be > 0"},
+				{Path: "session", Rule: "type", Message: "must be object, but it is array"},
+			},
+		},
+		{
+			Data: `{"person": {"name": "daniel rodrigo", "email": "daniel", "level": 9, "role": "guest", "code": "abc",
+				"phones": ["790", "abc", "791"]}, "session": {"expire": "13/02/2020", "ip": "::1", "id": "123"}}`,
+			Expected: []Violation{
+				{Path: "person.code", Rule: "pattern", Message: "must match the pattern ^[A-Z]{3}$"},
+				{Path: "person.email", Rule: "format", Message: "must be a valid email"},
+				{Path: "person.level", Rule: "maximum", Message: "must be <= 5"},
+				{Path: "person.name", Rule: "maxLength", Message: "must have at most 10 characters"},
+				{Path: "person.phones", Rule: "maxItems", Message: "must have at most 2 items"},
+				{Path: "person.phones.1", Rule: "pattern", Message: "must match the pattern ^[0-9-]+$"},
+				{Path: "person.role", Rule: "enum", Message: "must be one of [admin user]"},
+				{Path: "session.expire", Rule: "format", Message: "must be a valid date-time"},
+				{Path: "session.id", Rule: "format", Message: "must be a valid uuid"},
+				{Path: "session.ip", Rule: "format", Message: "must be a valid ipv4"},
+			},
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			m, err := NewFromJSON(test.Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = m.ValidateSchema(schema)
+
+			var actual []Violation
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				actual = validationErr.Violations
+			} else if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if !reflect.DeepEqual(test.Expected, actual) {
+				t.Errorf("expected %v, but got %v", test.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaGoValues(t *testing.T) {
+	schema, err := NewSchema(Map{
+		"properties": map[string]interface{}{
+			"labels": map[string]interface{}{"type": "object", "required": []string{"env"}},
+			"ports":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer", "minimum": 1}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := Map{"labels": map[string]string{"team": "core"}, "ports": []int{80, 0}}
+
+	expected := `the validation failed with 2 violations: labels.env: is required; ports.1: must be >= 1`
+	if err := m.ValidateSchema(schema); err == nil || err.Error() != expected {
+		t.Errorf("expected %s, but got %v", expected, err)
+	}
+}
+
+func TestNewSchemaError(t *testing.T) {
+	tests := []struct {
+		Schema string
+		Path   string
+	}{
+		{Schema: `{"type": 1}`, Path: ""},
+		{Schema: `{"properties": {"code": {"pattern": "["}}}`, Path: "properties.code.pattern"},
+		{Schema: `{"items": {"pattern": "(a"}}`, Path: "items.pattern"},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			var parseErr *ParseError
+			if _, err := NewSchemaFromJSON(test.Schema); !errors.As(err, &parseErr) || parseErr.Path != test.Path {
+				t.Errorf("expected *ParseError in %q, but got %v", test.Path, err)
+			}
+		})
+	}
+}
+
+func ExampleMap_ValidateSchema() {
+	schema, _ := NewSchemaFromJSON(`{
+		"type": "object",
+		"required": ["name"],
+		"properties": {"level": {"type": "integer", "minimum": 1}, "tags": {"items": {"type": "string"}}}
+	}`)
+
+	m, _ := NewFromJSON(`{"level": 0, "tags": ["new", 1]}`)
+
+	var validationErr *ValidationError
+	if err := m.ValidateSchema(schema); errors.As(err, &validationErr) {
+		for _, v := range validationErr.Violations {
+			fmt.Println(v.Path, v.Rule, v.Message)
+		}
+	}
+	// output:
+	// name required is required
+	// level minimum must be >= 1
+	// tags.1 type must be string, but it is integer
+}
@@ -0,0 +1,122 @@
+package nested
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Set stores the value in the position that you pass separately by . (dot),
+// the maps that don't exist in the middle of position are created as map[string]interface{}.
+// It returns *NotContainerError if a segment in the middle of position is not a map or a slice,
+// and *IndexError if an index is out of range of the slice, slices are never grown.
+// It returns ErrInvalidInputType if the Map is nil.
+func (m Map) Set(position string, value interface{}) error {
+	if m == nil {
+		return fmt.Errorf("%w: Set needs a non-nil Map", ErrInvalidInputType)
+	}
+
+	pos, err := parsePosition(position)
+	if err != nil {
+		return err
+	}
+
+	var t interface{} = map[string]interface{}(m)
+	for key, posKey := range pos[:len(pos)-1] {
+		v, ok := child(t, posKey)
+		if !ok || v == nil {
+			v = make(map[string]interface{})
+			if err := assign(t, pos[:key+1], v); err != nil {
+				return err
+			}
+		} else if !isContainer(v) {
+			return &NotContainerError{Path: joinSegments(pos[:key+1]), Value: v}
+		}
+
+		t = v
+	}
+
+	return assign(t, pos, value)
+}
+
+// assign stores value in node using the last segment of pos as key or index.
+func assign(node interface{}, pos []segment, value interface{}) error {
+	seg := pos[len(pos)-1]
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		n[seg.key] = value
+		return nil
+	case Map:
+		n[seg.key] = value
+		return nil
+	case []interface{}:
+		index, ok := sliceIndex(seg, len(n))
+		if !ok {
+			return indexError(pos, len(n))
+		}
+		n[index] = value
+		return nil
+	}
+
+	var elem reflect.Value
+	rv := reflect.ValueOf(node)
+	switch rv.Kind() {
+	case reflect.Map:
+		key, ok := mapKey(rv, seg.key)
+		if !ok {
+			return fmt.Errorf("%w: %q is not a key of map %q", ErrInvalidPosition, seg.key, joinSegments(pos[:len(pos)-1]))
+		}
+
+		v, err := assignable(rv.Type().Elem(), value)
+		if err != nil {
+			return err
+		}
+		rv.SetMapIndex(key, v)
+		return nil
+	case reflect.Slice:
+		index, ok := sliceIndex(seg, rv.Len())
+		if !ok {
+			return indexError(pos, rv.Len())
+		}
+		elem = rv.Index(index)
+	default:
+		return &NotContainerError{Path: joinSegments(pos[:len(pos)-1]), Value: node}
+	}
+
+	v, err := assignable(elem.Type(), value)
+	if err != nil {
+		return err
+	}
+	elem.Set(v)
+	return nil
+}
+
+// assignable returns value as reflect.Value that can be stored in a place of type typ,
+// nil is the zero value of typ.
+func assignable(typ reflect.Type, value interface{}) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(typ), nil
+	}
+
+	v := reflect.ValueOf(value)
+	if !v.Type().AssignableTo(typ) {
+		return reflect.Value{}, ErrInvalidInputType
+	}
+	return v, nil
+}
+
+// indexError returns *IndexError for the last segment of pos,
+// or ErrInvalidPosition if this segment is not a number.
+func indexError(pos []segment, length int) error {
+	seg := pos[len(pos)-1]
+	if !seg.isIndex {
+		return fmt.Errorf("%w: %q is not an index of slice %q", ErrInvalidPosition, seg.key, joinSegments(pos[:len(pos)-1]))
+	}
+
+	return &IndexError{Path: joinSegments(pos), Index: seg.index, Length: length}
+}
+
+// Set is helper for function Set from Map.
+func Set(position string, mapper map[string]interface{}, value interface{}) error {
+	return New(mapper).Set(position, value)
+}
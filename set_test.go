@@ -0,0 +1,162 @@
+package nested
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	newData := func() map[string]interface{} {
+		return map[string]interface{}{
+			"person": map[string]interface{}{
+				"name":   "Rodrigo",
+				"phones": []string{"473-68-42", "789-52-84"},
+				"images": []interface{}{
+					map[string]interface{}{"url": "www.loremipsum.com/1.jpg"},
+					nil,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		Parameter string
+		Value     interface{}
+		Expected  interface{}
+		Error     error
+	}{
+		{
+			Parameter: "person.name",
+			Value:     "Lopes",
+			Expected:  "Lopes",
+		},
+		{
+			Parameter: "session.token.value",
+			Value:     "62vsy29v8y4v248v5y97v1e21v35ce97",
+			Expected:  "62vsy29v8y4v248v5y97v1e21v35ce97",
+		},
+		{
+			Parameter: "person.phones[-1]",
+			Value:     "000-00-00",
+			Expected:  "000-00-00",
+		},
+		{
+			Parameter: "person.images.0.url",
+			Value:     "www.loremipsum.com/0.jpg",
+			Expected:  "www.loremipsum.com/0.jpg",
+		},
+		{
+			Parameter: "person.images.1.url",
+			Value:     "www.loremipsum.com/2.jpg",
+			Expected:  "www.loremipsum.com/2.jpg",
+		},
+		{
+			Parameter: "person.name.first",
+			Value:     "Rodrigo",
+			Error:     &NotContainerError{Path: "person.name", Value: "Rodrigo"},
+		},
+		{
+			Parameter: "person.phones.2",
+			Value:     "000-00-00",
+			Error:     &IndexError{Path: "person.phones.2", Index: 2, Length: 2},
+		},
+		{
+			Parameter: "person.phones.0",
+			Value:     123,
+			Error:     ErrInvalidInputType,
+		},
+		{
+			Parameter: "person[0",
+			Value:     "Rodrigo",
+			Error:     ErrInvalidPosition,
+		},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			mapper := newData()
+			err := Set(test.Parameter, mapper, test.Value)
+			if test.Error != nil {
+				if !reflect.DeepEqual(test.Error, err) && !errors.Is(err, test.Error) {
+					t.Errorf("[%s] expected error %v, but got %v", test.Parameter, test.Error, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("[%s] expected error nil, but got %s", test.Parameter, err)
+			}
+
+			actual, _ := Interface(test.Parameter, mapper)
+			if !reflect.DeepEqual(test.Expected, actual) {
+				t.Errorf("[%s] expected %T(%v), but got %T(%v)", test.Parameter, test.Expected, test.Expected, actual, actual)
+			}
+		})
+	}
+
+	t.Run("TestSetErrorAs", func(t *testing.T) {
+		err := Set("person.name.first", newData(), "Rodrigo")
+
+		var notContainer *NotContainerError
+		if !errors.As(err, &notContainer) || notContainer.Path != "person.name" {
+			t.Errorf("Expected a *NotContainerError for person.name, but got %v", err)
+		}
+	})
+
+	t.Run("TestSetNilMap", func(t *testing.T) {
+		var m Map
+		if err := m.Set("a.b", 1); !errors.Is(err, ErrInvalidInputType) {
+			t.Errorf("Expected ErrInvalidInputType for nil Map, but got %v", err)
+		}
+	})
+
+	t.Run("TestSetMapKinds", func(t *testing.T) {
+		mapper := map[string]interface{}{
+			"config": Map{},
+			"labels": map[string]string{},
+			"yaml":   map[interface{}]interface{}{1: map[interface{}]interface{}{}},
+		}
+
+		for position, value := range map[string]interface{}{
+			"config.name":      "nested",
+			"labels.app":       "nested",
+			"yaml.1.name":      "Rodrigo",
+			"yaml.person.name": "Rodrigo",
+		} {
+			if err := Set(position, mapper, value); err != nil {
+				t.Fatalf("[%s] expected error nil, but got %s", position, err)
+			}
+			if actual, _ := Interface(position, mapper); actual != value {
+				t.Errorf("[%s] expected %v, but got %v", position, value, actual)
+			}
+		}
+
+		if err := Set("labels.app", mapper, 1); err != ErrInvalidInputType {
+			t.Errorf("Expected error %v, but got %v", ErrInvalidInputType, err)
+		}
+	})
+
+	t.Run("TestSetKeyInSlice", func(t *testing.T) {
+		err := Set("person.phones.first", newData(), "000-00-00")
+		if !errors.Is(err, ErrInvalidPosition) {
+			t.Errorf("Expected error %v, but got %v", ErrInvalidPosition, err)
+		}
+	})
+}
+func ExampleSet() {
+	data := map[string]interface{}{}
+
+	err := Set("person.name", data, "Rodrigo")
+	fmt.Println(data, err)
+	// output: map[person:map[name:Rodrigo]] <nil>
+}
+func ExampleMap_Set() {
+	data := New(nil)
+
+	_ = data.Set("session.token", "62vsy29v8y4v248v5y97v1e21v35ce97")
+	err := data.Set("session.token.expire", "2018-08-08T18:00:00Z")
+	fmt.Println(data, err)
+	// output: map[session:map[token:62vsy29v8y4v248v5y97v1e21v35ce97]] the position "session.token" holds a string that cannot have nested values
+}
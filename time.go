@@ -0,0 +1,234 @@
+package nested
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// detectLayouts are the layouts tried by DetectLayout and TimeAny, the dates with / (slash) are day first.
+var detectLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"02/01/2006 15:04:05",
+	"02/01/2006",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.RubyDate,
+	time.UnixDate,
+	time.ANSIC,
+}
+
+// Layouts adds layouts to parse strings to time.Time, they are tried in order after the layout of Layout.
+func Layouts(layouts ...string) Option {
+	return func(o *options) {
+		o.layouts = append(o.layouts, layouts...)
+	}
+}
+
+// DetectLayout makes the strings be parsed to time.Time with the first layout that works from a list of
+// common layouts like time.RFC3339Nano, 2006-01-02, 02/01/2006 and time.RFC1123, and the numbers be
+// Unix epochs like Epoch(0).
+func DetectLayout() Option {
+	return func(o *options) {
+		o.detect = true
+		o.epoch = true
+	}
+}
+
+// Epoch makes the numbers be converted to time.Time as Unix epochs in unit, like time.Second or time.Millisecond.
+// With unit 0 it is detected by the size of number: seconds, milliseconds, microseconds or nanoseconds.
+// Numeric strings are epochs too with Lenient option.
+func Epoch(unit time.Duration) Option {
+	return func(o *options) {
+		o.epoch = true
+		o.epochUnit = unit
+	}
+}
+
+// Location makes the time.Time be returned in loc, the layouts without time zone are parsed in loc.
+func Location(loc *time.Location) Option {
+	return func(o *options) {
+		o.location = loc
+	}
+}
+
+// GetTimeAny returns the time value from position that you passed by argument like TimeAny.
+func (m Map) GetTimeAny(position string, layouts ...string) time.Time {
+	value, _ := m.TimeAny(position, layouts...)
+	return value
+}
+
+// TimeAny returns the time.Time value from position parsed with the first layout that works and a bool if found the field,
+// without layouts it detects the layout like DetectLayout. The numbers are Unix epochs with the unit detected by their size.
+// Use Get[time.Time] with the options Layouts, Epoch and Location to choose the unit and the location.
+func (m Map) TimeAny(position string, layouts ...string) (time.Time, bool) {
+	value, err := m.TimeAnyE(position, layouts...)
+	return value, err == nil
+}
+
+// TimeAnyE returns the time.Time value from position like TimeAny,
+// it returns *ParseError if the value cannot be parsed with any layout.
+func (m Map) TimeAnyE(position string, layouts ...string) (time.Time, error) {
+	if len(layouts) == 0 {
+		return GetE[time.Time](m, position, DetectLayout())
+	}
+	return GetE[time.Time](m, position, Layouts(layouts...), Epoch(0))
+}
+
+// toTime converts value found in position to time.Time, strings are parsed with the layouts of options and
+// numbers are Unix epochs with Epoch option, the epochs that overflow return *TypeMismatchError with ErrOverflow.
+func toTime(position string, value interface{}, o options) (time.Time, error) {
+	if o.epoch {
+		t, err := epochTime(value, o)
+		if err == nil {
+			return o.in(t), nil
+		}
+		if err != ErrInvalidInputType {
+			return time.Time{}, mismatch(position, timeType.String(), value, err)
+		}
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}, mismatch(position, timeType.String(), value, nil)
+	}
+
+	layouts := o.timeLayouts()
+
+	var err error
+	for _, layout := range layouts {
+		var t time.Time
+		if o.location == nil {
+			t, err = time.Parse(layout, s)
+		} else {
+			t, err = time.ParseInLocation(layout, s, o.location)
+		}
+
+		if err == nil {
+			return o.in(t), nil
+		}
+	}
+
+	return time.Time{}, &ParseError{Path: position, Layout: strings.Join(layouts, " or "), Err: err}
+}
+
+// timeLayouts returns the layouts to parse strings to time.Time, by default it is time.RFC3339.
+func (o options) timeLayouts() []string {
+	var layouts []string
+	if o.layout != "" {
+		layouts = append(layouts, o.layout)
+	}
+	layouts = append(layouts, o.layouts...)
+	if o.detect {
+		layouts = append(layouts, detectLayouts...)
+	}
+
+	if len(layouts) == 0 {
+		return []string{time.RFC3339}
+	}
+	return layouts
+}
+
+// in returns t in the location of Location option.
+func (o options) in(t time.Time) time.Time {
+	if o.location == nil {
+		return t
+	}
+	return t.In(o.location)
+}
+
+// unixToInternal are the seconds from the year 1, where time.Time starts, to the Unix epoch.
+const unixToInternal = 62135596800
+
+// minEpoch and maxEpoch are the Unix epochs in seconds that time.Time can represent,
+// out of them the date of time.Time overflows like the year 292277026596 for math.MinInt64.
+const (
+	minEpoch = -9223371966579724800 - unixToInternal
+	maxEpoch = math.MaxInt64 - unixToInternal
+)
+
+// epochTime returns the number value as Unix epoch in UTC in the unit of Epoch option,
+// it returns ErrInvalidInputType if value is not a number and ErrOverflow if the epoch doesn't fit in time.Time.
+func epochTime(value interface{}, o options) (time.Time, error) {
+	if valueKind(value) != "number" && !o.lenient {
+		return time.Time{}, ErrInvalidInputType
+	}
+
+	if n, err := toInt64(value, o); err == nil {
+		unit := o.epochUnit
+		if unit <= 0 {
+			var ok bool
+			if unit, ok = epochUnit(n); !ok {
+				return time.Time{}, fmt.Errorf("%w: %d does not fit in time.Time", ErrOverflow, n)
+			}
+		}
+
+		// n*unit can overflow int64 and unit can be any duration like 1500ms, so the nanoseconds are a big.Int.
+		nanos := new(big.Int).Mul(big.NewInt(n), big.NewInt(int64(unit)))
+		sec, nsec := new(big.Int).DivMod(nanos, big.NewInt(int64(time.Second)), new(big.Int))
+		if !sec.IsInt64() || sec.Int64() < minEpoch || sec.Int64() > maxEpoch {
+			return time.Time{}, fmt.Errorf("%w: %d does not fit in time.Time", ErrOverflow, n)
+		}
+		return time.Unix(sec.Int64(), nsec.Int64()).UTC(), nil
+	}
+
+	f, err := toFloat64(value, o)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return time.Time{}, ErrInvalidInputType
+	}
+
+	unit := o.epochUnit
+	if unit <= 0 {
+		unit = time.Nanosecond
+		if math.Abs(f) < math.MaxInt64 {
+			unit, _ = epochUnit(int64(f))
+		}
+	}
+
+	sec, frac := math.Modf(f * float64(unit) / float64(time.Second))
+	if sec < minEpoch || sec >= maxEpoch {
+		return time.Time{}, fmt.Errorf("%w: %v does not fit in time.Time", ErrOverflow, f)
+	}
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC(), nil
+}
+
+// epochUnit returns the unit of Unix epoch n by its size, seconds until the year 5138
+// and then milliseconds, microseconds and nanoseconds. It returns false for math.MinInt64
+// that has no size in int64.
+func epochUnit(n int64) (time.Duration, bool) {
+	if n == math.MinInt64 {
+		return 0, false
+	}
+	if n < 0 {
+		n = -n
+	}
+
+	switch {
+	case n < 1e11:
+		return time.Second, true
+	case n < 1e14:
+		return time.Millisecond, true
+	case n < 1e17:
+		return time.Microsecond, true
+	}
+	return time.Nanosecond, true
+}
+
+// TimeAny is helper for function TimeAny from Map.
+func TimeAny(position string, mapper map[string]interface{}, layouts ...string) (time.Time, bool) {
+	return New(mapper).TimeAny(position, layouts...)
+}
+
+// GetTimeAny is helper for function GetTimeAny from Map.
+func GetTimeAny(position string, mapper map[string]interface{}, layouts ...string) time.Time {
+	return New(mapper).GetTimeAny(position, layouts...)
+}
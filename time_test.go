@@ -0,0 +1,179 @@
+package nested
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTimeAny(t *testing.T) {
+	m := New(map[string]interface{}{
+		"rfc3339":    "1987-01-29T19:00:00Z",
+		"nano":       "1987-01-29T19:00:00.123456789Z",
+		"date":       "1987-01-29",
+		"birth":      "29/01/1987",
+		"local":      "1987-01-29 19:00:00",
+		"seconds":    538945200,
+		"millis":     int64(538945200123),
+		"micros":     538945200123456.0,
+		"nanos":      int64(538945200123456789),
+		"fractional": 538945200.5,
+		"json":       json.Number("538945200"),
+		"numeric":    "538945200",
+		"invalid":    "yesterday",
+		"bool":       true,
+		"negative":   -86400,
+		"rfc1123":    "Thu, 29 Jan 1987 19:00:00 UTC",
+		"zone":       "1987-01-29T19:00:00+01:00",
+		"day":        "29/01/1987 19:00:00",
+	})
+
+	base := time.Date(1987, 1, 29, 19, 0, 0, 0, time.UTC)
+	date := time.Date(1987, 1, 29, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Parameter string
+		Layouts   []string
+		Expected  time.Time
+		Found     bool
+	}{
+		{Parameter: "rfc3339", Expected: base, Found: true},
+		{Parameter: "nano", Expected: base.Add(123456789), Found: true},
+		{Parameter: "date", Expected: date, Found: true},
+		{Parameter: "birth", Expected: date, Found: true},
+		{Parameter: "local", Expected: base, Found: true},
+		{Parameter: "rfc1123", Expected: base, Found: true},
+		{Parameter: "day", Expected: base, Found: true},
+		{Parameter: "seconds", Expected: base, Found: true},
+		{Parameter: "millis", Expected: base.Add(123 * time.Millisecond), Found: true},
+		{Parameter: "micros", Expected: base.Add(123456 * time.Microsecond), Found: true},
+		{Parameter: "nanos", Expected: base.Add(123456789), Found: true},
+		{Parameter: "fractional", Expected: base.Add(500 * time.Millisecond), Found: true},
+		{Parameter: "json", Expected: base, Found: true},
+		{Parameter: "negative", Expected: time.Unix(-86400, 0).UTC(), Found: true},
+		{Parameter: "numeric", Expected: time.Time{}, Found: false},
+		{Parameter: "invalid", Expected: time.Time{}, Found: false},
+		{Parameter: "bool", Expected: time.Time{}, Found: false},
+		{Parameter: "missing", Expected: time.Time{}, Found: false},
+		{Parameter: "birth", Layouts: []string{time.RFC3339, "02/01/2006"}, Expected: date, Found: true},
+		{Parameter: "date", Layouts: []string{time.RFC3339, "02/01/2006"}, Expected: time.Time{}, Found: false},
+		{Parameter: "seconds", Layouts: []string{time.RFC3339}, Expected: base, Found: true},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, found := TimeAny(test.Parameter, m, test.Layouts...)
+			if found != test.Found {
+				t.Errorf("[%s] expected found %v, but got %v", test.Parameter, test.Found, found)
+			}
+			if !actual.Equal(test.Expected) {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.Expected, actual)
+			}
+		})
+	}
+
+	if zone := m.GetTimeAny("zone"); !zone.Equal(base.Add(-time.Hour)) {
+		t.Errorf("expected %v, but got %v", base.Add(-time.Hour), zone)
+	}
+}
+
+func TestTimeOptions(t *testing.T) {
+	m := New(map[string]interface{}{
+		"millis":  538945200123,
+		"seconds": "538945200",
+		"local":   "1987-01-29 19:00:00",
+		"utc":     "1987-01-29T19:00:00Z",
+		"birth":   "29/01/1987",
+		"huge":    int64(9e18),
+		"float":   1e300,
+		"min":     int64(math.MinInt64),
+		"steps":   3,
+	})
+
+	lisbon := time.FixedZone("WET", 0)
+	tokyo := time.FixedZone("JST", 9*3600)
+	base := time.Date(1987, 1, 29, 19, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Parameter string
+		Options   []Option
+		Expected  time.Time
+		Found     bool
+	}{
+		{Parameter: "millis", Options: []Option{Epoch(time.Millisecond)}, Expected: base.Add(123 * time.Millisecond), Found: true},
+		{Parameter: "millis", Options: []Option{Epoch(time.Second)}, Expected: time.Unix(538945200123, 0), Found: true},
+		{Parameter: "millis", Expected: time.Time{}, Found: false},
+		{Parameter: "seconds", Options: []Option{Epoch(time.Second)}, Expected: time.Time{}, Found: false},
+		{Parameter: "seconds", Options: []Option{Epoch(time.Second), Lenient()}, Expected: base, Found: true},
+		{Parameter: "local", Options: []Option{Layout("2006-01-02 15:04:05"), Location(tokyo)}, Expected: base.Add(-9 * time.Hour), Found: true},
+		{Parameter: "local", Options: []Option{Layout("2006-01-02 15:04:05"), Location(lisbon)}, Expected: base, Found: true},
+		{Parameter: "utc", Options: []Option{Location(tokyo)}, Expected: base, Found: true},
+		{Parameter: "birth", Options: []Option{Layouts(time.RFC3339, "02/01/2006")}, Expected: base.Add(-19 * time.Hour), Found: true},
+		{Parameter: "birth", Options: []Option{DetectLayout()}, Expected: base.Add(-19 * time.Hour), Found: true},
+		{Parameter: "huge", Options: []Option{Epoch(time.Hour)}, Expected: time.Time{}, Found: false},
+		{Parameter: "huge", Options: []Option{Epoch(time.Second)}, Expected: time.Unix(9e18, 0), Found: true},
+		{Parameter: "float", Options: []Option{Epoch(0)}, Expected: time.Time{}, Found: false},
+		{Parameter: "min", Options: []Option{Epoch(0)}, Expected: time.Time{}, Found: false},
+		{Parameter: "min", Options: []Option{Epoch(time.Second)}, Expected: time.Time{}, Found: false},
+		{Parameter: "min", Options: []Option{Epoch(time.Nanosecond)}, Expected: time.Unix(0, math.MinInt64), Found: true},
+		{Parameter: "steps", Options: []Option{Epoch(1500 * time.Millisecond)}, Expected: time.Unix(4, 5e8), Found: true},
+		{Parameter: "steps", Options: []Option{Epoch(300 * time.Millisecond)}, Expected: time.Unix(0, 9e8), Found: true},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			actual, found := Get[time.Time](m, test.Parameter, test.Options...)
+			if found != test.Found {
+				t.Errorf("[%s] expected found %v, but got %v", test.Parameter, test.Found, found)
+			}
+			if !actual.Equal(test.Expected) {
+				t.Errorf("[%s] expected %v, but got %v", test.Parameter, test.Expected, actual)
+			}
+		})
+	}
+
+	if local, _ := m.Time("local", "2006-01-02 15:04:05", Location(tokyo)); local.Location() != tokyo {
+		t.Errorf("expected location %v, but got %v", tokyo, local.Location())
+	}
+
+	var parseErr *ParseError
+	if _, err := m.TimeE("birth", time.RFC3339, Layouts(time.RFC1123)); !errors.As(err, &parseErr) || parseErr.Layout != time.RFC3339+" or "+time.RFC1123 {
+		t.Errorf("expected *ParseError with both layouts, but got %v", err)
+	}
+
+	for _, test := range []struct {
+		Parameter string
+		Unit      time.Duration
+	}{
+		{Parameter: "huge", Unit: time.Hour},
+		{Parameter: "float", Unit: 0},
+		{Parameter: "float", Unit: time.Second},
+		{Parameter: "min", Unit: 0},
+		{Parameter: "min", Unit: time.Second},
+	} {
+		var mismatchErr *TypeMismatchError
+		if _, err := GetE[time.Time](m, test.Parameter, Epoch(test.Unit)); !errors.As(err, &mismatchErr) || !errors.Is(err, ErrOverflow) {
+			t.Errorf("[%s] expected *TypeMismatchError with ErrOverflow, but got %v", test.Parameter, err)
+		}
+	}
+}
+
+func ExampleMap_TimeAny() {
+	m, _ := NewFromJSON(`{"created": "2020-02-13T21:42:03Z", "birth": "29/01/1987", "updated": 1581630123000}`)
+
+	for _, position := range []string{"created", "birth", "updated"} {
+		t, found := m.TimeAny(position)
+		fmt.Println(t.Format(time.RFC3339), found)
+	}
+
+	updated, _ := m.Time("updated", "", Epoch(time.Millisecond), Location(time.FixedZone("BRT", -3*3600)))
+	fmt.Println(updated.Format(time.RFC3339))
+	// output:
+	// 2020-02-13T21:42:03Z true
+	// 1987-01-29T00:00:00Z true
+	// 2020-02-13T21:42:03Z true
+	// 2020-02-13T18:42:03-03:00
+}
@@ -0,0 +1,389 @@
+package nested
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UUIDValue is an universally unique identifier like 6ba7b810-9dad-11d1-80b4-00c04fd430c8 returned by Map.UUID.
+type UUIDValue [16]byte
+
+// ParseUUID parses s in the form 6ba7b810-9dad-11d1-80b4-00c04fd430c8, with or without the prefix urn:uuid:
+// and with lower or upper case letters.
+func ParseUUID(s string) (UUIDValue, error) {
+	var id UUIDValue
+
+	text := strings.TrimPrefix(strings.ToLower(s), "urn:uuid:")
+	if len(text) != 36 || text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+		return id, fmt.Errorf("invalid UUID %q", s)
+	}
+
+	b, err := hex.DecodeString(text[0:8] + text[9:13] + text[14:18] + text[19:23] + text[24:])
+	if err != nil {
+		return id, fmt.Errorf("invalid UUID %q", s)
+	}
+
+	copy(id[:], b)
+	return id, nil
+}
+
+// String returns the UUID in the form 6ba7b810-9dad-11d1-80b4-00c04fd430c8.
+func (id UUIDValue) String() string {
+	b := hex.EncodeToString(id[:])
+	return b[0:8] + "-" + b[8:12] + "-" + b[12:16] + "-" + b[16:20] + "-" + b[20:]
+}
+
+// GetDuration returns the time.Duration value from position that you passed by argument
+func (m Map) GetDuration(position string) time.Duration {
+	value, _ := m.Duration(position)
+	return value
+}
+
+// Duration returns the time.Duration value from position parsed like time.ParseDuration (1h30m, 250ms)
+// and a bool if found the field, if it doesn't find the field or it cannot be parsed the returns is 0 and false.
+func (m Map) Duration(position string) (time.Duration, bool) {
+	value, err := m.DurationE(position)
+	return value, err == nil
+}
+
+// DurationE returns the time.Duration value from position like Duration,
+// it returns *ParseError if the value cannot be parsed.
+func (m Map) DurationE(position string) (time.Duration, error) {
+	return parseE(m, position, "duration", false, time.ParseDuration)
+}
+
+// GetURL returns the *url.URL value from position that you passed by argument
+func (m Map) GetURL(position string) *url.URL {
+	value, _ := m.URL(position)
+	return value
+}
+
+// URL returns the *url.URL value from position parsed like url.Parse and a bool if found the field,
+// if it doesn't find the field or it cannot be parsed the returns is nil and false.
+func (m Map) URL(position string) (*url.URL, bool) {
+	value, err := m.URLE(position)
+	return value, err == nil
+}
+
+// URLE returns the *url.URL value from position like URL,
+// it returns *ParseError if the value cannot be parsed.
+func (m Map) URLE(position string) (*url.URL, error) {
+	return parseE(m, position, "url", false, url.Parse)
+}
+
+// GetIP returns the net.IP value from position that you passed by argument
+func (m Map) GetIP(position string) net.IP {
+	value, _ := m.IP(position)
+	return value
+}
+
+// IP returns the net.IP value from position parsed like net.ParseIP (IPv4 or IPv6) and a bool if found the field,
+// if it doesn't find the field or it cannot be parsed the returns is nil and false.
+func (m Map) IP(position string) (net.IP, bool) {
+	value, err := m.IPE(position)
+	return value, err == nil
+}
+
+// IPE returns the net.IP value from position like IP,
+// it returns *ParseError if the value cannot be parsed.
+func (m Map) IPE(position string) (net.IP, error) {
+	return parseE(m, position, "ip", false, func(s string) (net.IP, error) {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", s)
+		}
+		return ip, nil
+	})
+}
+
+// GetAddr returns the netip.Addr value from position that you passed by argument
+func (m Map) GetAddr(position string) netip.Addr {
+	value, _ := m.Addr(position)
+	return value
+}
+
+// Addr returns the netip.Addr value from position parsed like netip.ParseAddr and a bool if found the field,
+// if it doesn't find the field or it cannot be parsed the returns is the zero netip.Addr and false.
+func (m Map) Addr(position string) (netip.Addr, bool) {
+	value, err := m.AddrE(position)
+	return value, err == nil
+}
+
+// AddrE returns the netip.Addr value from position like Addr,
+// it returns *ParseError if the value cannot be parsed.
+func (m Map) AddrE(position string) (netip.Addr, error) {
+	return parseE(m, position, "ip", false, netip.ParseAddr)
+}
+
+// GetPrefix returns the netip.Prefix value from position that you passed by argument
+func (m Map) GetPrefix(position string) netip.Prefix {
+	value, _ := m.Prefix(position)
+	return value
+}
+
+// Prefix returns the netip.Prefix value from position parsed like netip.ParsePrefix (10.0.0.0/8) and a bool if found the field,
+// if it doesn't find the field or it cannot be parsed the returns is the zero netip.Prefix and false.
+func (m Map) Prefix(position string) (netip.Prefix, bool) {
+	value, err := m.PrefixE(position)
+	return value, err == nil
+}
+
+// PrefixE returns the netip.Prefix value from position like Prefix,
+// it returns *ParseError if the value cannot be parsed.
+func (m Map) PrefixE(position string) (netip.Prefix, error) {
+	return parseE(m, position, "cidr", false, netip.ParsePrefix)
+}
+
+// GetUUID returns the UUID value from position that you passed by argument
+func (m Map) GetUUID(position string) UUIDValue {
+	value, _ := m.UUID(position)
+	return value
+}
+
+// UUID returns the UUID value from position parsed like ParseUUID and a bool if found the field,
+// if it doesn't find the field or it cannot be parsed the returns is the zero UUID and false.
+func (m Map) UUID(position string) (UUIDValue, bool) {
+	value, err := m.UUIDE(position)
+	return value, err == nil
+}
+
+// UUIDE returns the UUID value from position like UUID,
+// it returns *ParseError if the value cannot be parsed.
+func (m Map) UUIDE(position string) (UUIDValue, error) {
+	return parseE(m, position, "uuid", false, ParseUUID)
+}
+
+// GetBigInt returns the *big.Int value from position that you passed by argument
+func (m Map) GetBigInt(position string) *big.Int {
+	value, _ := m.BigInt(position)
+	return value
+}
+
+// BigInt returns the *big.Int value from position and a bool if found the field, the strings are decimal
+// integers of any size and the numbers that are integers are converted too.
+// if it doesn't find the field or it cannot be parsed the returns is nil and false.
+func (m Map) BigInt(position string) (*big.Int, bool) {
+	value, err := m.BigIntE(position)
+	return value, err == nil
+}
+
+// BigIntE returns the *big.Int value from position like BigInt,
+// it returns *ParseError if the value cannot be parsed.
+func (m Map) BigIntE(position string) (*big.Int, error) {
+	return parseE(m, position, "big.Int", true, func(s string) (*big.Int, error) {
+		value, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", s)
+		}
+		return value, nil
+	})
+}
+
+// GetBigFloat returns the *big.Float value from position that you passed by argument
+func (m Map) GetBigFloat(position string) *big.Float {
+	value, _ := m.BigFloat(position)
+	return value
+}
+
+// BigFloat returns the *big.Float value from position and a bool if found the field, the strings are decimal
+// numbers with the precision of all their digits and the numbers are converted too.
+// if it doesn't find the field or it cannot be parsed the returns is nil and false.
+func (m Map) BigFloat(position string) (*big.Float, bool) {
+	value, err := m.BigFloatE(position)
+	return value, err == nil
+}
+
+// BigFloatE returns the *big.Float value from position like BigFloat,
+// it returns *ParseError if the value cannot be parsed.
+func (m Map) BigFloatE(position string) (*big.Float, error) {
+	return parseE(m, position, "big.Float", true, func(s string) (*big.Float, error) {
+		prec := uint(len(s)) * 4
+		if prec < 64 {
+			prec = 64
+		}
+
+		value, _, err := big.ParseFloat(s, 10, prec, big.ToNearestEven)
+		return value, err
+	})
+}
+
+// GetBytes returns the []byte value from position that you passed by argument
+func (m Map) GetBytes(position string) []byte {
+	value, _ := m.Bytes(position)
+	return value
+}
+
+// Bytes returns the []byte value from position decoded from base64 and a bool if found the field,
+// the standard and URL encodings with or without padding are accepted.
+// if it doesn't find the field or it cannot be decoded the returns is nil and false.
+func (m Map) Bytes(position string) ([]byte, bool) {
+	value, err := m.BytesE(position)
+	return value, err == nil
+}
+
+// BytesE returns the []byte value from position like Bytes,
+// it returns *ParseError if the value cannot be decoded.
+func (m Map) BytesE(position string) ([]byte, error) {
+	return parseE(m, position, "base64", false, decodeBase64)
+}
+
+// decodeBase64 decodes s with the first base64 encoding that works.
+func decodeBase64(s string) ([]byte, error) {
+	encodings := []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding}
+
+	var err error
+	for _, encoding := range encodings {
+		var b []byte
+		if b, err = encoding.DecodeString(s); err == nil {
+			return b, nil
+		}
+	}
+	return nil, err
+}
+
+// parseE returns the value from position parsed with parse, the values that are already T are returned as they are.
+// With numbers the numeric values are parsed from their decimal text. It returns *ParseError with layout
+// if the value cannot be parsed.
+func parseE[T any](m Map, position, layout string, numbers bool, parse func(string) (T, error)) (T, error) {
+	var zero T
+
+	valueTmp, err := m.InterfaceE(position)
+	if err != nil {
+		return zero, err
+	}
+
+	if value, ok := valueTmp.(T); ok {
+		return value, nil
+	}
+
+	s, ok := valueTmp.(string)
+	if !ok && numbers && valueKind(valueTmp) == "number" {
+		s, ok = numberText(valueTmp), true
+	}
+	if !ok {
+		return zero, mismatch(position, fmt.Sprintf("%T", zero), valueTmp, nil)
+	}
+
+	value, err := parse(s)
+	if err != nil {
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) {
+			err = numErr.Err
+		}
+		return zero, &ParseError{Path: position, Layout: layout, Err: err}
+	}
+	return value, nil
+}
+
+// numberText returns the number value as decimal text without exponent.
+func numberText(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case json.Number:
+		return string(v)
+	}
+	return fmt.Sprint(value)
+}
+
+// Duration is helper for function Duration from Map.
+func Duration(position string, mapper map[string]interface{}) (time.Duration, bool) {
+	return New(mapper).Duration(position)
+}
+
+// GetDuration is helper for function GetDuration from Map.
+func GetDuration(position string, mapper map[string]interface{}) time.Duration {
+	return New(mapper).GetDuration(position)
+}
+
+// URL is helper for function URL from Map.
+func URL(position string, mapper map[string]interface{}) (*url.URL, bool) {
+	return New(mapper).URL(position)
+}
+
+// GetURL is helper for function GetURL from Map.
+func GetURL(position string, mapper map[string]interface{}) *url.URL {
+	return New(mapper).GetURL(position)
+}
+
+// IP is helper for function IP from Map.
+func IP(position string, mapper map[string]interface{}) (net.IP, bool) {
+	return New(mapper).IP(position)
+}
+
+// GetIP is helper for function GetIP from Map.
+func GetIP(position string, mapper map[string]interface{}) net.IP {
+	return New(mapper).GetIP(position)
+}
+
+// Addr is helper for function Addr from Map.
+func Addr(position string, mapper map[string]interface{}) (netip.Addr, bool) {
+	return New(mapper).Addr(position)
+}
+
+// GetAddr is helper for function GetAddr from Map.
+func GetAddr(position string, mapper map[string]interface{}) netip.Addr {
+	return New(mapper).GetAddr(position)
+}
+
+// Prefix is helper for function Prefix from Map.
+func Prefix(position string, mapper map[string]interface{}) (netip.Prefix, bool) {
+	return New(mapper).Prefix(position)
+}
+
+// GetPrefix is helper for function GetPrefix from Map.
+func GetPrefix(position string, mapper map[string]interface{}) netip.Prefix {
+	return New(mapper).GetPrefix(position)
+}
+
+// UUID is helper for function UUID from Map.
+func UUID(position string, mapper map[string]interface{}) (UUIDValue, bool) {
+	return New(mapper).UUID(position)
+}
+
+// GetUUID is helper for function GetUUID from Map.
+func GetUUID(position string, mapper map[string]interface{}) UUIDValue {
+	return New(mapper).GetUUID(position)
+}
+
+// BigInt is helper for function BigInt from Map.
+func BigInt(position string, mapper map[string]interface{}) (*big.Int, bool) {
+	return New(mapper).BigInt(position)
+}
+
+// GetBigInt is helper for function GetBigInt from Map.
+func GetBigInt(position string, mapper map[string]interface{}) *big.Int {
+	return New(mapper).GetBigInt(position)
+}
+
+// BigFloat is helper for function BigFloat from Map.
+func BigFloat(position string, mapper map[string]interface{}) (*big.Float, bool) {
+	return New(mapper).BigFloat(position)
+}
+
+// GetBigFloat is helper for function GetBigFloat from Map.
+func GetBigFloat(position string, mapper map[string]interface{}) *big.Float {
+	return New(mapper).GetBigFloat(position)
+}
+
+// Bytes is helper for function Bytes from Map.
+func Bytes(position string, mapper map[string]interface{}) ([]byte, bool) {
+	return New(mapper).Bytes(position)
+}
+
+// GetBytes is helper for function GetBytes from Map.
+func GetBytes(position string, mapper map[string]interface{}) []byte {
+	return New(mapper).GetBytes(position)
+}
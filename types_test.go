@@ -0,0 +1,133 @@
+package nested
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRichTypes(t *testing.T) {
+	m := New(map[string]interface{}{
+		"server": map[string]interface{}{
+			"timeout": "1m30s",
+			"ttl":     30,
+			"url":     "https://user@example.com:8080/api?v=1",
+			"ip":      "10.0.0.1",
+			"ipv6":    "::1",
+			"network": "10.0.0.0/8",
+			"id":      "6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+			"urn":     "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			"secret":  "aGVsbG8gd29ybGQ=",
+			"raw":     "aGVsbG8gd29ybGQ",
+			"invalid": "%%%",
+			"typed":   time.Second,
+		},
+		"balance": map[string]interface{}{
+			"text":   "123456789012345678901234567890",
+			"number": 12.0,
+			"json":   json.Number("98765432109876543210"),
+			"price":  "0.1234567890123456789",
+			"float":  19.9,
+		},
+	})
+
+	id := UUIDValue{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	expectedURL, _ := url.Parse("https://user@example.com:8080/api?v=1")
+	bigText, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	bigJSON, _ := new(big.Int).SetString("98765432109876543210", 10)
+
+	tests := []struct {
+		Actual   interface{}
+		Found    bool
+		Expected interface{}
+	}{
+		{Actual: first(m.Duration("server.timeout")), Found: second(m.Duration("server.timeout")), Expected: 90 * time.Second},
+		{Actual: first(m.Duration("server.typed")), Found: second(m.Duration("server.typed")), Expected: time.Second},
+		{Actual: first(m.Duration("server.ttl")), Found: second(m.Duration("server.ttl")), Expected: time.Duration(0)},
+		{Actual: first(m.Duration("server.invalid")), Found: second(m.Duration("server.invalid")), Expected: time.Duration(0)},
+		{Actual: first(m.URL("server.url")), Found: second(m.URL("server.url")), Expected: expectedURL},
+		{Actual: first(m.URL("server.invalid")), Found: second(m.URL("server.invalid")), Expected: (*url.URL)(nil)},
+		{Actual: first(m.IP("server.ip")), Found: second(m.IP("server.ip")), Expected: net.ParseIP("10.0.0.1")},
+		{Actual: first(m.IP("server.network")), Found: second(m.IP("server.network")), Expected: net.IP(nil)},
+		{Actual: first(m.Addr("server.ipv6")), Found: second(m.Addr("server.ipv6")), Expected: netip.IPv6Loopback()},
+		{Actual: first(m.Addr("server.missing")), Found: second(m.Addr("server.missing")), Expected: netip.Addr{}},
+		{Actual: first(m.Prefix("server.network")), Found: second(m.Prefix("server.network")), Expected: netip.MustParsePrefix("10.0.0.0/8")},
+		{Actual: first(m.Prefix("server.ip")), Found: second(m.Prefix("server.ip")), Expected: netip.Prefix{}},
+		{Actual: first(m.UUID("server.id")), Found: second(m.UUID("server.id")), Expected: id},
+		{Actual: first(m.UUID("server.urn")), Found: second(m.UUID("server.urn")), Expected: id},
+		{Actual: first(m.UUID("server.ip")), Found: second(m.UUID("server.ip")), Expected: UUIDValue{}},
+		{Actual: first(m.BigInt("balance.text")), Found: second(m.BigInt("balance.text")), Expected: bigText},
+		{Actual: first(m.BigInt("balance.number")), Found: second(m.BigInt("balance.number")), Expected: big.NewInt(12)},
+		{Actual: first(m.BigInt("balance.json")), Found: second(m.BigInt("balance.json")), Expected: bigJSON},
+		{Actual: first(m.BigInt("balance.float")), Found: second(m.BigInt("balance.float")), Expected: (*big.Int)(nil)},
+		{Actual: first(m.Bytes("server.secret")), Found: second(m.Bytes("server.secret")), Expected: []byte("hello world")},
+		{Actual: first(m.Bytes("server.raw")), Found: second(m.Bytes("server.raw")), Expected: []byte("hello world")},
+		{Actual: first(m.Bytes("server.invalid")), Found: second(m.Bytes("server.invalid")), Expected: []byte(nil)},
+	}
+
+	for key, test := range tests {
+		t.Run(fmt.Sprintf("Test #%d", key), func(t *testing.T) {
+			if found := !reflect.ValueOf(test.Expected).IsZero(); found != test.Found {
+				t.Errorf("expected found %v, but got %v", found, test.Found)
+			}
+			if !reflect.DeepEqual(test.Expected, test.Actual) {
+				t.Errorf("expected %v, but got %v", test.Expected, test.Actual)
+			}
+		})
+	}
+
+	if price := m.GetBigFloat("balance.price"); price == nil || price.Text('f', 19) != "0.1234567890123456789" {
+		t.Errorf("expected 0.1234567890123456789, but got %v", price)
+	}
+	if value := GetBigFloat("balance.float", m); value == nil || value.String() != "19.9" {
+		t.Errorf("expected 19.9, but got %v", value)
+	}
+	if value, found := UUID("server.id", m); !found || value.String() != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Errorf("expected 6ba7b810-9dad-11d1-80b4-00c04fd430c8, but got %v", value)
+	}
+}
+
+func TestRichTypesError(t *testing.T) {
+	m := New(map[string]interface{}{"timeout": "soon", "ttl": 30})
+
+	var parseErr *ParseError
+	if _, err := m.DurationE("timeout"); !errors.As(err, &parseErr) || parseErr.Layout != "duration" || !errors.Is(err, ErrInvalidInputType) {
+		t.Errorf("expected *ParseError with layout duration, but got %v", err)
+	}
+
+	var mismatchErr *TypeMismatchError
+	if _, err := m.DurationE("ttl"); !errors.As(err, &mismatchErr) || mismatchErr.Want != "time.Duration" {
+		t.Errorf("expected *TypeMismatchError, but got %v", err)
+	}
+
+	var notFound *PathNotFoundError
+	if _, err := m.UUIDE("id"); !errors.As(err, &notFound) {
+		t.Errorf("expected *PathNotFoundError, but got %v", err)
+	}
+}
+
+func first[T any](value T, _ bool) T {
+	return value
+}
+
+func second[T any](_ T, found bool) bool {
+	return found
+}
+
+func ExampleMap_Duration() {
+	m, _ := NewFromJSON(`{"server": {"timeout": "1m30s", "network": "10.0.0.0/8", "key": "aGVsbG8="}}`)
+
+	timeout, _ := m.Duration("server.timeout")
+	network, _ := m.Prefix("server.network")
+	key, _ := m.Bytes("server.key")
+
+	fmt.Println(timeout, network.Contains(netip.MustParseAddr("10.1.2.3")), string(key))
+	// output: 1m30s true hello
+}